@@ -4,7 +4,7 @@
 package main
 
 import (
-	"errors"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -13,8 +13,10 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/pprof"
+	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/go-logr/zerologr"
@@ -36,11 +38,23 @@ const (
  platform    : %s/%s
 `
 
-	defaultConfigFlag  = "gotagger.json"
+	bumpFilesCommand = "bump-files"
+	configCommand    = "config"
+	goVersionCommand = "go-version"
+	historyCommand   = "history"
+
+	configSchemaCommand = "schema"
+	configShowCommand   = "show"
+
+	// sources of config option values
+	sourceDefault = "default"
+	sourceEnv     = "env"
+	sourceFile    = "file"
+	sourceFlag    = "flag"
+
 	defaultDirtyFlag   = "none"
 	defaultModulesFlag = true
 	defaultPrefixFlag  = "v"
-	defaultRemoteFlag  = "origin"
 )
 
 var (
@@ -61,16 +75,44 @@ type GoTagger struct {
 	out *log.Logger
 	err *log.Logger
 
+	// the command to run, or empty to print the current version
+	command string
+
+	// the sub-command of the config command
+	configCommand string
+
+	// environment variables and flags that were set
+	envSet  map[string]bool
+	flagSet map[string]bool
+
+	// the source of each config option that was set by an environment
+	// variable or flag, indexed by option name
+	sources map[string]string
+
 	// command-line options
+	check          bool
 	configFile     string
+	contains       string
+	format         string
 	debug          bool
 	dirtyIncrement string
+	firstParent    bool
 	force          bool
+	goCommitVar    string
+	goImportPath   string
+	goModules      stringsFlag
+	goPackage      string
+	goVar          string
+	ldflags        bool
 	modules        bool
-	pathFilter     string
+	outputFormat   string
+	pathFilters    stringsFlag
 	pushTag        bool
 	remoteName     string
+	rev            string
 	showVersion    bool
+	signingKey     string
+	signTag        bool
 	tagRelease     bool
 	versionPrefix  string
 }
@@ -81,30 +123,74 @@ func (g *GoTagger) Run() int {
 	g.out = log.New(g.Stdout, "", 0)
 	g.err = log.New(g.Stderr, "", 0)
 
+	// the first argument may be a command
+	args := g.Args
+	if len(args) > 0 && isCommand(args[0]) {
+		g.command, args = args[0], args[1:]
+	}
+
+	// the config command requires a sub-command
+	if g.command == configCommand && len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		g.configCommand, args = args[0], args[1:]
+	}
+
 	flags := flag.NewFlagSet(AppName, flag.ContinueOnError)
 	flags.SetOutput(g.Stderr)
 
-	flags.StringVar(&g.configFile, "config", g.stringEnv("config", defaultConfigFlag), "path to the gotagger configuration file.")
-	flags.StringVar(&g.dirtyIncrement, "dirty", g.stringEnv("dirty", defaultDirtyFlag), "how to increment the version for a dirty checkout [minor, patch, none]")
+	flags.StringVar(&g.configFile, "config", g.stringEnv("config", ""), "path to the gotagger configuration file. Defaults to the first config file found in PATH or its parents")
 	flags.BoolVar(&g.debug, "debug", false, "enable debug output")
-	flags.BoolVar(&g.force, "force", g.boolEnv("force", false), "force creation of a tag")
+	flags.BoolVar(&g.firstParent, "first-parent", g.boolEnv("first_parent", false), "only follow the first parent of merge commits")
 	flags.BoolVar(&g.modules, "modules", g.boolEnv("modules", defaultModulesFlag), "enable go module versioning")
-	flags.StringVar(&g.pathFilter, "path", "", "filter commits by path")
-	flags.BoolVar(&g.pushTag, "push", g.boolEnv("push", false), "push the just created tag, implies -release")
-	flags.StringVar(&g.remoteName, "remote", g.stringEnv("remote", defaultRemoteFlag), "name of the remote to push tags to")
+	flags.Var(&g.pathFilters, "path", "filter commits by path. May be a glob pattern, and may be repeated to version several paths")
+	flags.StringVar(&g.rev, "rev", g.stringEnv("rev", ""), "git revision to version and tag instead of HEAD")
 	flags.BoolVar(&g.showVersion, "version", false, "show version information")
-	flags.BoolVar(&g.tagRelease, "release", g.boolEnv("release", false), "tag HEAD with the current version if it is a release commit")
 	flags.StringVar(&g.versionPrefix, "prefix", g.stringEnv("prefix", defaultPrefixFlag), "set a prefix for versions")
 
+	// command specific options
+	switch g.command {
+	case bumpFilesCommand:
+		flags.BoolVar(&g.check, "check", false, "check that the version files contain the version of the latest release, instead of updating them")
+	case goVersionCommand:
+		flags.StringVar(&g.goCommitVar, "commit-var", "", "name of the variable the linker flags set to the commit hash (default \"Commit\")")
+		flags.StringVar(&g.goImportPath, "import-path", "", "import path of the package whose variables the linker flags set (default \"main\")")
+		flags.BoolVar(&g.ldflags, "ldflags", false, "print the linker flags that set the version and commit, instead of writing version files")
+		flags.Var(&g.goModules, "module", "name of a go module to generate the version of. May be repeated. Defaults to every go module")
+		flags.StringVar(&g.goPackage, "package", "", "package name of the version files. Defaults to the package of the go files in each module")
+		flags.StringVar(&g.goVar, "var", "", "name of the version variable (default \"Version\")")
+	case historyCommand:
+		flags.StringVar(&g.contains, "contains", "", "show the first release of each module that contains this commit")
+	case configCommand:
+		if g.configCommand == configShowCommand {
+			flags.StringVar(&g.format, "format", "text", "output format [text, json]")
+		}
+	}
+
+	// options that control tagging
+	if g.command != historyCommand && g.command != bumpFilesCommand && g.command != goVersionCommand {
+		flags.StringVar(&g.dirtyIncrement, "dirty", g.stringEnv("dirty", defaultDirtyFlag), "how to increment the version for a dirty checkout [minor, patch, none]")
+		flags.BoolVar(&g.force, "force", g.boolEnv("force", false), "force creation of a tag")
+		flags.BoolVar(&g.pushTag, "push", g.boolEnv("push", false), "push the just created tag, implies -release")
+		flags.StringVar(&g.remoteName, "remote", g.stringEnv("remote", ""), "name of the remote to push tags to (default \"origin\")")
+		flags.BoolVar(&g.signTag, "sign", g.boolEnv("sign", false), "sign the tags gotagger creates")
+		flags.StringVar(&g.signingKey, "u", g.stringEnv("signing_key", ""), "key id to sign tags with, implies -sign")
+		flags.BoolVar(&g.tagRelease, "release", g.boolEnv("release", false), "tag the revision selected by -rev, HEAD by default, with the current version if it is a release commit")
+		flags.StringVar(&g.outputFormat, "output-format", g.stringEnv("output_format", ""), "format of the printed versions, tags are always semantic versions [semver, pep440, maven, debian] (default \"semver\")")
+	}
+
 	// profiling options
 	cpuprofile := flags.String("cpuprofile", "", "write cpu profile to file")
 	memprofile := flags.String("memprofile", "", "write memory profile to file")
 
 	g.setUsage(flags)
-	if err := flags.Parse(g.Args); err != nil {
+	if err := flags.Parse(args); err != nil {
 		return genericErrorExitCode
 	}
 
+	g.flagSet = make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		g.flagSet[f.Name] = true
+	})
+
 	zerolog.SetGlobalLevel(zerolog.Disabled)
 	if g.debug {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
@@ -161,21 +247,36 @@ func (g *GoTagger) Run() int {
 		return successExitCode
 	}
 
+	if g.command == configCommand {
+		switch g.configCommand {
+		case "":
+			g.err.Println("error: missing config command")
+			flags.Usage()
+			return genericErrorExitCode
+		case configSchemaCommand:
+			return g.runConfigSchema()
+		case configShowCommand:
+			if g.format != "text" && g.format != "json" {
+				g.err.Printf("error: invalid format: %s\n", g.format)
+				return genericErrorExitCode
+			}
+		default:
+			g.err.Printf("error: unknown config command: %s\n", g.configCommand)
+			flags.Usage()
+			return genericErrorExitCode
+		}
+	}
+
 	// Find the git repo
 	path := flags.Arg(0)
-	if path == "" {
-		path = g.WorkingDir
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(g.WorkingDir, path)
 	}
 
-	// validate that path filter is a directory in the git repo
-	info, err := os.Stat(filepath.Join(path, g.pathFilter))
+	// expand and validate the path filters
+	pathFilters, err := expandPathFilters(path, g.pathFilters)
 	if err != nil {
-		g.err.Printf("error: invalid path filter %s: %v", g.pathFilter, err)
-		return genericErrorExitCode
-	}
-
-	if !info.IsDir() {
-		g.err.Printf("error: invalid path filter %s: not a directory", g.pathFilter)
+		g.err.Println("error:", err)
 		return genericErrorExitCode
 	}
 
@@ -186,40 +287,81 @@ func (g *GoTagger) Run() int {
 	}
 
 	r.SetLogger(rootLogger)
+	r.SetWarningOutput(g.Stderr)
 
-	if g.configFile != "" {
-		logger.Info("reading config file", "path", g.configFile)
-		data, err := os.ReadFile(g.configFile)
-		// ignore a missing "default" config file
-		if !(g.configFile == defaultConfigFlag && errors.Is(err, os.ErrNotExist)) {
-			if err != nil {
-				g.err.Println("error:", err)
-				return genericErrorExitCode
-			}
+	configFile := g.configFile
+	if configFile != "" {
+		logger.Info("parsing config file", "path", configFile)
+		if err := r.Config.ParseFile(configFile); err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+	} else {
+		logger.Info("searching for config file", "path", path)
+		configFile, err = r.LoadConfig(path)
+		if err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
 
-			logger.Info("parsing config data", "path", g.configFile)
-			err = r.Config.ParseJSON(data)
-			if err != nil {
-				g.err.Println("error:", err)
-				return genericErrorExitCode
-			}
+		if configFile != "" {
+			logger.Info("loaded config file", "path", configFile)
 		}
 	}
 
-	r.Config.CreateTag = g.tagRelease || g.pushTag || g.force
-	r.Config.Force = g.force
-	r.Config.PushTag = g.pushTag
-	r.Config.RemoteName = g.remoteName
-
-	//nolint: gosimple // makes this consistent with other flags,
-	// and avoids hard to understand double negatives
-	if g.modules != defaultModulesFlag {
+	// command-line options override the config file
+	g.sources = make(map[string]string)
+	if src := g.source("release", "release"); src != "" {
+		r.Config.CreateTag = g.tagRelease
+		g.sources["createTag"] = src
+	}
+	if src := g.source("force", "force"); src != "" {
+		r.Config.Force = g.force
+		g.sources["force"] = src
+		if g.force {
+			r.Config.CreateTag = true
+			g.sources["createTag"] = src
+		}
+	}
+	if src := g.source("push", "push"); src != "" {
+		r.Config.PushTag = g.pushTag
+		g.sources["pushTag"] = src
+		if g.pushTag {
+			r.Config.CreateTag = true
+			g.sources["createTag"] = src
+		}
+	}
+	if src := g.source("remote", "remote"); src != "" {
+		r.Config.RemoteName = g.remoteName
+		g.sources["remoteName"] = src
+	}
+	// rev is only set by the -rev flag or the environment
+	if g.source("rev", "rev") != "" {
+		r.Config.Rev = g.rev
+	}
+	if src := g.source("sign", "sign"); src != "" {
+		r.Config.SignTags = g.signTag
+		g.sources["signTags"] = src
+	}
+	if src := g.source("u", "signing_key"); src != "" && g.signingKey != "" {
+		r.Config.SignTags = true
+		r.Config.SigningKey = g.signingKey
+		g.sources["signTags"] = src
+		g.sources["signingKey"] = src
+	}
+	if src := g.source("first-parent", "first_parent"); src != "" {
+		r.Config.FirstParent = g.firstParent
+		g.sources["firstParent"] = src
+	}
+	if src := g.source("modules", "modules"); src != "" {
 		r.Config.IgnoreModules = !g.modules
+		g.sources["ignoreModules"] = src
 	}
-	if g.versionPrefix != defaultPrefixFlag {
+	if src := g.source("prefix", "prefix"); src != "" {
 		r.Config.VersionPrefix = g.versionPrefix
+		g.sources["versionPrefix"] = src
 	}
-	if g.dirtyIncrement != defaultDirtyFlag {
+	if src := g.source("dirty", "dirty"); src != "" {
 		inc, err := mapper.Convert(g.dirtyIncrement)
 		if err != nil {
 			g.err.Println("error:", err)
@@ -231,9 +373,35 @@ func (g *GoTagger) Run() int {
 			return genericErrorExitCode
 		}
 		r.Config.DirtyWorktreeIncrement = inc
+		g.sources["incrementDirtyWorktree"] = src
+	}
+	if src := g.source("output-format", "output_format"); src != "" {
+		if _, err := gotagger.RenderVersion("0.0.0", g.outputFormat); err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+		r.Config.OutputFormat = g.outputFormat
+		g.sources["outputFormat"] = src
 	}
-	if g.pathFilter != "" {
-		r.Config.Paths = []string{g.pathFilter}
+	if len(pathFilters) > 0 {
+		r.Config.Paths = pathFilters
+		g.sources["paths"] = sourceFlag
+	}
+
+	if g.command == configCommand {
+		return g.runConfigShow(r, configFile)
+	}
+
+	if g.command == historyCommand {
+		return g.runHistory(r)
+	}
+
+	if g.command == bumpFilesCommand {
+		return g.runBumpFiles(r)
+	}
+
+	if g.command == goVersionCommand {
+		return g.runGoVersion(r)
 	}
 
 	start := time.Now()
@@ -247,6 +415,22 @@ func (g *GoTagger) Run() int {
 		return genericErrorExitCode
 	}
 
+	// label the version of each path when the -path flags select several paths
+	if len(pathFilters) > 1 && len(versions) == len(pathFilters) {
+		w := tabwriter.NewWriter(g.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "PATH\tVERSION")
+		for i, version := range versions {
+			fmt.Fprintf(w, "%s\t%s\n", pathFilters[i], version)
+		}
+
+		if err := w.Flush(); err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+
+		return successExitCode
+	}
+
 	for _, version := range versions {
 		g.out.Println(version)
 	}
@@ -254,6 +438,319 @@ func (g *GoTagger) Run() int {
 	return successExitCode
 }
 
+// isCommand returns true if arg is a gotagger command.
+//
+// Commands always win, so a PATH named like a command
+// must be passed as ./PATH or after --.
+func isCommand(arg string) bool {
+	switch arg {
+	case bumpFilesCommand, configCommand, goVersionCommand, historyCommand:
+		return true
+	default:
+		return false
+	}
+}
+
+// expandPathFilters returns the directories under dir that match the path filters.
+//
+// Path filters may be glob patterns, and every filter must match at least one
+// directory within dir. The directories are returned relative to dir,
+// in the order they were matched, without duplicates.
+func expandPathFilters(dir string, filters []string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, filter := range filters {
+		if rel, err := filepath.Rel(dir, filepath.Join(dir, filter)); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid path filter %s: not within %s", filter, dir)
+		}
+
+		matches, err := filepath.Glob(filepath.Join(dir, filter))
+		if err != nil {
+			return nil, fmt.Errorf("invalid path filter %s: %w", filter, err)
+		}
+
+		if len(matches) == 0 {
+			// a filter that is not a pattern must exist
+			if !strings.ContainsAny(filter, `*?[\`) {
+				_, err := os.Stat(filepath.Join(dir, filter))
+				return nil, fmt.Errorf("invalid path filter %s: %w", filter, err)
+			}
+			return nil, fmt.Errorf("invalid path filter %s: no matching directories", filter)
+		}
+
+		var dirs []string
+		for _, match := range matches {
+			// patterns like "*" should not match the git directory
+			if info, err := os.Stat(match); err != nil || !info.IsDir() || filepath.Base(match) == ".git" {
+				continue
+			}
+
+			rel, err := filepath.Rel(dir, match)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path filter %s: %w", filter, err)
+			}
+
+			dirs = append(dirs, filepath.ToSlash(rel))
+		}
+
+		if len(dirs) == 0 {
+			if len(matches) == 1 && matches[0] == filepath.Join(dir, filter) {
+				return nil, fmt.Errorf("invalid path filter %s: not a directory", filter)
+			}
+			return nil, fmt.Errorf("invalid path filter %s: no matching directories", filter)
+		}
+
+		for _, d := range dirs {
+			if !seen[d] {
+				seen[d] = true
+				paths = append(paths, d)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// stringsFlag is a command-line flag that may be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// runBumpFiles updates the version files,
+// or checks them if the -check flag is set.
+func (g *GoTagger) runBumpFiles(r *gotagger.Gotagger) int {
+	if len(r.Config.VersionFiles) == 0 {
+		g.err.Println("error: no version files are configured")
+		return genericErrorExitCode
+	}
+
+	if g.check {
+		if err := r.CheckFiles(); err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+
+		return successExitCode
+	}
+
+	files, err := r.BumpFiles()
+	if err != nil {
+		g.err.Println("error:", err)
+		return genericErrorExitCode
+	}
+
+	for _, fn := range files {
+		g.out.Println(fn)
+	}
+
+	return successExitCode
+}
+
+// runGoVersion writes the go version files,
+// or prints the linker flags if the -ldflags flag is set.
+func (g *GoTagger) runGoVersion(r *gotagger.Gotagger) int {
+	// command-line options override the config file
+	if g.flagSet["commit-var"] {
+		r.Config.GoVersion.CommitVar = g.goCommitVar
+	}
+	if g.flagSet["import-path"] {
+		r.Config.GoVersion.ImportPath = g.goImportPath
+	}
+	if g.flagSet["package"] {
+		r.Config.GoVersion.Package = g.goPackage
+	}
+	if g.flagSet["var"] {
+		r.Config.GoVersion.Var = g.goVar
+	}
+
+	var (
+		lines []string
+		err   error
+	)
+	if g.ldflags {
+		lines, err = r.LDFlags(g.goModules...)
+	} else {
+		lines, err = r.GenerateGoVersion(g.goModules...)
+	}
+	if err != nil {
+		g.err.Println("error:", err)
+		return genericErrorExitCode
+	}
+
+	for _, line := range lines {
+		g.out.Println(line)
+	}
+
+	return successExitCode
+}
+
+// runConfigSchema prints the JSON Schema of the config file.
+func (g *GoTagger) runConfigSchema() int {
+	schema, err := gotagger.ConfigSchema()
+	if err != nil {
+		g.err.Println("error:", err)
+		return genericErrorExitCode
+	}
+
+	g.out.Println(string(schema))
+
+	return successExitCode
+}
+
+// configOption is the effective value of a config option and its source.
+type configOption struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// runConfigShow prints the effective configuration of r,
+// and where each value came from.
+func (g *GoTagger) runConfigShow(r *gotagger.Gotagger, configFile string) int {
+	fileOptions := map[string]bool{}
+	if configFile != "" {
+		names, err := gotagger.FileOptions(configFile)
+		if err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+
+		for _, name := range names {
+			fileOptions[name] = true
+		}
+
+		// pushTag and force imply createTag
+		if r.Config.CreateTag && (fileOptions["pushTag"] || fileOptions["force"]) {
+			fileOptions["createTag"] = true
+		}
+	}
+
+	options := make(map[string]configOption)
+	names := make([]string, 0)
+	for name, value := range r.Config.Options() {
+		source := sourceDefault
+		if src, ok := g.sources[name]; ok {
+			source = src
+		} else if fileOptions[name] {
+			source = sourceFile
+		}
+
+		options[name] = configOption{Value: value, Source: source}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if g.format == "json" {
+		data, err := json.MarshalIndent(struct {
+			ConfigFile string                  `json:"configFile"`
+			Options    map[string]configOption `json:"options"`
+		}{configFile, options}, "", "  ")
+		if err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+
+		g.out.Println(string(data))
+
+		return successExitCode
+	}
+
+	if configFile == "" {
+		configFile = "none"
+	}
+	g.out.Println("config file:", configFile)
+	g.out.Println()
+
+	w := tabwriter.NewWriter(g.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "OPTION\tVALUE\tSOURCE")
+	for _, name := range names {
+		value, err := json.Marshal(options[name].Value)
+		if err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, value, options[name].Source)
+	}
+
+	if err := w.Flush(); err != nil {
+		g.err.Println("error:", err)
+		return genericErrorExitCode
+	}
+
+	return successExitCode
+}
+
+// runHistory prints the release history of the repository.
+func (g *GoTagger) runHistory(r *gotagger.Gotagger) int {
+	if g.contains != "" {
+		releases, err := r.FirstReleases(g.contains)
+		if err != nil {
+			g.err.Println("error:", err)
+			return genericErrorExitCode
+		}
+
+		for _, release := range releases {
+			g.out.Println(release.Tag)
+		}
+
+		return successExitCode
+	}
+
+	releases, err := r.History()
+	if err != nil {
+		g.err.Println("error:", err)
+		return genericErrorExitCode
+	}
+
+	w := tabwriter.NewWriter(g.Stdout, 0, 4, 2, ' ', 0)
+	header := "PATH"
+	if len(releases) > 0 && releases[0].Module != "" {
+		header = "MODULE"
+	} else if len(releases) > 0 && releases[0].Component != "" {
+		header = "COMPONENT"
+	}
+	fmt.Fprintf(w, "%s\tVERSION\tCOMMIT\tDATE\tCOMMITS\n", header)
+	for _, release := range releases {
+		name := release.Module
+		if name == "" {
+			name = release.Component
+		}
+		if name == "" {
+			name = release.Path
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", name, release.Tag, release.Commit, release.Date.Format("2006-01-02"), release.Commits)
+	}
+
+	if err := w.Flush(); err != nil {
+		g.err.Println("error:", err)
+		return genericErrorExitCode
+	}
+
+	return successExitCode
+}
+
+// source returns where the value of the command-line option flag came from:
+// sourceFlag if it was set on the command line,
+// sourceEnv if it was set by the environment variable env,
+// or the empty string if it has its default value.
+func (g *GoTagger) source(flag, env string) string {
+	switch {
+	case g.flagSet[flag]:
+		return sourceFlag
+	case g.envSet[env]:
+		return sourceEnv
+	default:
+		return ""
+	}
+}
+
 func (g *GoTagger) boolEnv(env string, def bool) bool {
 	if val, ok := getEnv(env); ok {
 		b, err := strconv.ParseBool(val)
@@ -261,6 +758,7 @@ func (g *GoTagger) boolEnv(env string, def bool) bool {
 			// We use fatal here since we cannot return an error.
 			g.err.Fatalf("error: cannot parse GOTAGGER_%s as a boolean value: %v\n", strings.ToUpper(env), err)
 		}
+		g.setEnv(env)
 		return b
 	}
 
@@ -269,12 +767,20 @@ func (g *GoTagger) boolEnv(env string, def bool) bool {
 
 func (g *GoTagger) stringEnv(env, def string) string {
 	if val, ok := getEnv(env); ok {
+		g.setEnv(env)
 		return val
 	}
 
 	return def
 }
 
+func (g *GoTagger) setEnv(env string) {
+	if g.envSet == nil {
+		g.envSet = make(map[string]bool)
+	}
+	g.envSet[env] = true
+}
+
 func getEnv(env string) (string, bool) {
 	env = "GOTAGGER_" + strings.ToUpper(env)
 	return os.LookupEnv(env)
@@ -282,9 +788,14 @@ func getEnv(env string) (string, bool) {
 
 const (
 	usagePrefix = `Usage: %s [OPTION]... [PATH]
+  or:  %[1]s history [OPTION]... [PATH]
+  or:  %[1]s bump-files [OPTION]... [PATH]
+  or:  %[1]s go-version [OPTION]... [PATH]
+  or:  %[1]s config COMMAND [OPTION]... [PATH]
 Print the current version of the project to standard output.
 
-With no PATH the current directory is used.
+With no PATH the current directory is used. A PATH named like a command
+must be passed as ./PATH or after --.
 
 Options:
   -help
@@ -296,8 +807,9 @@ version in the current branch and then determining what type of commits were
 made since that commit by parsing the commit messages using the conventional
 commit standard.
 
-If the -release flag is set and the HEAD commit uses the 'release' type, then
-gotagger will create a tag using the version it calculates. For projects that
+If the -release flag is set and the commit being versioned, HEAD or the
+revision selected by -rev, uses the 'release' type, then gotagger will create a
+tag on that commit using the version it calculates. For projects that
 contain multiple go modules, tag specific modules by including them in the
 release commit using the Modules footer:
 
@@ -305,18 +817,144 @@ release commit using the Modules footer:
 
 	Modules: github.com/example/repo/module, github.com/example/repo/other/module
 
+Modules are separated by commas or whitespace, and can be module names, module
+paths relative to the root of the repository, or glob patterns such as
+github.com/example/repo/plugins/*. Use * or changed to release every module
+with commits since its latest version.
+
+The -sign flag causes gotagger to sign the tags it creates using the default
+key for the committer, or the key specified by the -u flag. The signature
+format is controlled by the gpg.format git config setting, or by the
+signingFormat config file option.
+
+The -rev flag causes gotagger to version and tag a git revision other than
+HEAD. Go modules are found in the tree of that revision, and the state of the
+worktree is ignored.
+
+The -first-parent flag causes gotagger to only follow the first parent of merge
+commits. The commits and tags of merged branches are ignored, and each merge
+commit counts as a single commit that changed every file its branch changed.
+
+Unless the -config flag is set, gotagger loads the first config file it finds
+in PATH or its parents, up to the top of the git repository. In each directory
+gotagger looks for gotagger.json, .gotagger.json, .gotagger.yaml,
+.gotagger.yml, and .gotagger.toml, in that order.
+
 The -path flag causes gotagger to filter commit history by paths. This is useful
 for using gotagger with git repositories that contain multiple pieces that
-should be versioned separately. A path filter must exist and must be a
-directory.
+should be versioned separately. The flag can be repeated, and can be a glob
+pattern. Each path filter must match at least one directory. When versioning
+more than one path, gotagger prints the version of each path next to the path.
+`
+)
+
+const (
+	configUsagePrefix = `Usage: %s config COMMAND [OPTION]... [PATH]
+Inspect the gotagger configuration.
+
+Commands:
+  schema
+        print the JSON Schema of the config file
+  show
+        print the effective configuration, and the source of each value
+
+Options:
+  -help
+        show this help message
+`
+	configUsageSuffix = `
+The JSON Schema can be used by editors to validate and complete config files.
+
+The show command merges the defaults, the config file, GOTAGGER_* environment
+variables, and command-line flags, in increasing order of precedence, and
+prints the resulting value of each config option along with its source:
+default, file, env, or flag. Use -format json for machine readable output.
+`
+
+	bumpFilesUsagePrefix = `Usage: %s bump-files [OPTION]... [PATH]
+Write the current version of the project to the version files,
+and print the files that changed to standard output.
+
+With no PATH the current directory is used.
+
+Options:
+  -help
+        show this help message
+`
+	bumpFilesUsageSuffix = `
+Version files are configured by the versionFiles option of the config file.
+Each version file is a path, relative to the top of the git repository, and
+either a regular expression that matches the version, or the dotted key of
+the version in a JSON, YAML, or TOML file.
+
+Run bump-files before creating a release commit, so that the release includes
+the updated files.
+
+If the -check flag is set, then gotagger does not change any files, and exits
+with an error if a version file does not contain the version of the latest
+release.
+`
+
+	goVersionUsagePrefix = `Usage: %s go-version [OPTION]... [PATH]
+Write a go file that declares the current version to the directory of each
+go module, and print the files that changed to standard output.
+
+With no PATH the current directory is used.
+
+Options:
+  -help
+        show this help message
+`
+	goVersionUsageSuffix = `
+The generated file is named version.go, unless the goVersion.file option of the
+config file is set, and declares a variable named by the -var flag that holds
+the version, including the version prefix.
+
+If the -ldflags flag is set, then gotagger does not write any files, and
+prints the linker flags that set the version and commit variables of a package
+for each go module instead. Use the -module flag to select the module of the
+binary:
+
+    go build -ldflags "$(gotagger go-version -ldflags -module example.com/app)" ./cmd/app
+`
+
+	historyUsagePrefix = `Usage: %s history [OPTION]... [PATH]
+Print the release history of the project to standard output.
+
+With no PATH the current directory is used.
+
+Options:
+  -help
+        show this help message
+`
+	historyUsageSuffix = `
+The release history lists every version tag of each go module, or of the
+project if it does not use go modules, that is reachable from HEAD, along with
+the tagged commit, the commit date, and the number of commits since the
+previous release.
+
+If the -contains flag is set, then gotagger prints the first release of each
+module changed by that commit that contains it.
 `
 )
 
 func (g *GoTagger) setUsage(fs *flag.FlagSet) {
+	prefix, suffix := usagePrefix, usageSuffix
+	switch g.command {
+	case configCommand:
+		prefix, suffix = configUsagePrefix, configUsageSuffix
+	case historyCommand:
+		prefix, suffix = historyUsagePrefix, historyUsageSuffix
+	case bumpFilesCommand:
+		prefix, suffix = bumpFilesUsagePrefix, bumpFilesUsageSuffix
+	case goVersionCommand:
+		prefix, suffix = goVersionUsagePrefix, goVersionUsageSuffix
+	}
+
 	fs.Usage = func() {
-		g.err.Printf(usagePrefix, AppName)
+		g.err.Printf(prefix, AppName)
 		fs.PrintDefaults()
-		g.err.Print(usageSuffix)
+		g.err.Print(suffix)
 	}
 }
 
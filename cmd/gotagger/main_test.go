@@ -5,6 +5,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sassoftware/gotagger"
 	"github.com/sassoftware/gotagger/internal/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -183,6 +185,190 @@ func TestGoTagger(t *testing.T) {
 			wantOut:   "v1.1.0\n",
 			extraTest: assertTag("v1.1.0"),
 		},
+		{
+			title:   "rev flag",
+			args:    []string{"-rev", "other"},
+			wantOut: "v0.1.0\n",
+		},
+		{
+			title:   "release rev",
+			args:    []string{"-release", "-rev", "HEAD~1"},
+			wantOut: "v1.1.0\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				createReleaseCommit(t, repo, path)
+				testutils.CommitFile(t, repo, path, "foo", "feat: after the release", []byte(`foo`))
+			},
+			extraTest: assertTag("v1.1.0"),
+		},
+		{
+			title:   "first parent flag",
+			args:    []string{"-first-parent"},
+			wantOut: "v1.1.0\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				testutils.Git(t, path, "checkout", "-q", "-b", "feature")
+				testutils.CommitFile(t, repo, path, "feature", "feat!: wip", []byte(`feature`))
+				testutils.Git(t, path, "checkout", "-q", "-")
+				testutils.MergeBranch(t, path, "feature", "Merge pull request #1 from feature\n\nfix: add feature")
+			},
+		},
+		{
+			title:   "discover config file",
+			args:    []string{},
+			wantOut: "release-0.1.0\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.toml"), []byte(`versionPrefix = "release-"`), 0600))
+			},
+		},
+		{
+			title:   "discover config file from path",
+			args:    []string{"sub"},
+			wantOut: "release-0.1.0\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte(`versionPrefix: release-`), 0600))
+				require.NoError(t, os.Mkdir(filepath.Join(path, "sub"), 0700))
+			},
+		},
+		{
+			title:   "components",
+			args:    []string{},
+			wantOut: "app/v0.1.0\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("components:\n  - name: app\n    paths: [.]\n"), 0600))
+			},
+		},
+		{
+			title:   "output format",
+			args:    []string{"-output-format", "pep440"},
+			wantOut: "1.1.0\n",
+		},
+		{
+			title:   "invalid output format",
+			args:    []string{"-output-format", "rpm"},
+			wantErr: "error: invalid output format: rpm\n",
+			wantRc:  1,
+		},
+		{
+			title:   "bump files",
+			args:    []string{"bump-files"},
+			wantOut: "VERSION\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("versionFiles:\n  - path: VERSION\n    pattern: .+\n"), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(path, "VERSION"), []byte("1.0.0\n"), 0600))
+			},
+			extraTest: func(t *testing.T, repo *git.Repository, path string, stdout, stderr *bytes.Buffer) {
+				data, err := os.ReadFile(filepath.Join(path, "VERSION"))
+				require.NoError(t, err)
+				assert.Equal(t, "1.1.0\n", string(data))
+			},
+		},
+		{
+			title:  "bump files check",
+			args:   []string{"bump-files", "-check"},
+			wantRc: 0,
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("versionFiles:\n  - path: VERSION\n    pattern: .+\n"), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(path, "VERSION"), []byte("1.0.0\n"), 0600))
+			},
+		},
+		{
+			title:   "bump files check out of sync",
+			args:    []string{"bump-files", "-check"},
+			wantErr: "error: version files are out of sync with the latest release:\nVERSION: version is 0.9.0, want 1.0.0\n",
+			wantRc:  1,
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("versionFiles:\n  - path: VERSION\n    pattern: .+\n"), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(path, "VERSION"), []byte("0.9.0\n"), 0600))
+			},
+		},
+		{
+			title:   "bump files without version files",
+			args:    []string{"bump-files"},
+			wantErr: "error: no version files are configured\n",
+			wantRc:  1,
+		},
+		{
+			title:   "go version",
+			args:    []string{"go-version", "-var", "AppVersion"},
+			wantOut: "version.go\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, "go.mod"), []byte("module foo\n"), 0600))
+				require.NoError(t, os.WriteFile(filepath.Join(path, "main.go"), []byte("package main\n"), 0600))
+			},
+			extraTest: func(t *testing.T, repo *git.Repository, path string, stdout, stderr *bytes.Buffer) {
+				data, err := os.ReadFile(filepath.Join(path, "version.go"))
+				require.NoError(t, err)
+				assert.Equal(t, "// Code generated by gotagger. DO NOT EDIT.\n\npackage main\n\n// AppVersion is the version of foo.\nvar AppVersion = \"v1.1.0\"\n", string(data))
+			},
+		},
+		{
+			title:   "go version without go modules",
+			args:    []string{"go-version", "-ldflags"},
+			wantErr: "error: go versions require go modules\n",
+			wantRc:  1,
+		},
+		{
+			title:   "go version invalid package",
+			args:    []string{"go-version", "-package", "my-package"},
+			wantErr: "error: invalid package name: my-package\n",
+			wantRc:  1,
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, "go.mod"), []byte("module foo\n"), 0600))
+			},
+		},
+		{
+			title:   "config flag",
+			args:    []string{"-config", "missing.json"},
+			wantErr: "error: open missing.json: no such file or directory",
+			wantRc:  1,
+		},
+		{
+			title:   "config file options",
+			args:    []string{},
+			wantErr: "failed with exit code 128: fatal: 'upstream' does not appear to be a git repository",
+			wantRc:  1,
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("pushTag: true\nremoteName: upstream\n"), 0600))
+				createReleaseCommit(t, repo, path)
+			},
+			extraTest: assertNoTag("v1.1.0"),
+		},
+		{
+			title:   "config file unknown option",
+			args:    []string{},
+			wantErr: `error: invalid config file %s/gotagger.json: unknown config option "pushTags", did you mean "pushTag"?`,
+			wantRc:  1,
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				require.NoError(t, os.WriteFile(filepath.Join(path, "gotagger.json"), []byte(`{"pushTags": true}`), 0600))
+			},
+		},
+		{
+			title:   "config missing command",
+			args:    []string{"config"},
+			wantErr: "error: missing config command\nUsage: gotagger config COMMAND",
+			wantRc:  1,
+		},
+		{
+			title:   "config unknown command",
+			args:    []string{"config", "foo"},
+			wantErr: "error: unknown config command: foo\n",
+			wantRc:  1,
+		},
+		{
+			title:   "history contains",
+			args:    []string{"history", "-contains", "HEAD~1"},
+			wantOut: "v1.0.0\n",
+		},
+		{
+			title:   "history contains unreleased",
+			args:    []string{"history", "-contains", "HEAD"},
+			wantOut: "",
+		},
+		{
+			title:   "contains without history",
+			args:    []string{"-contains", "HEAD"},
+			wantErr: "flag provided but not defined: -contains\n",
+			wantRc:  1,
+		},
 		{
 			title:   "filter to baz subdirectory",
 			args:    []string{"-path", "baz"},
@@ -201,6 +387,46 @@ func TestGoTagger(t *testing.T) {
 				}
 			},
 		},
+		{
+			title:   "multiple path filters",
+			args:    []string{"-path", "a", "-path", "b"},
+			wantOut: "PATH  VERSION\na     v1.1.0\nb     v1.0.1\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				testutils.CommitFile(t, repo, path, filepath.Join("a", "file"), "feat: add a", []byte("a\n"))
+				testutils.CommitFile(t, repo, path, filepath.Join("b", "file"), "fix: fix b", []byte("b\n"))
+			},
+		},
+		{
+			title:   "path filter glob",
+			args:    []string{"-path", "[ab]", "-path", "a"},
+			wantOut: "PATH  VERSION\na     v1.1.0\nb     v1.0.1\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				testutils.CommitFile(t, repo, path, filepath.Join("a", "file"), "feat: add a", []byte("a\n"))
+				testutils.CommitFile(t, repo, path, filepath.Join("b", "file"), "fix: fix b", []byte("b\n"))
+			},
+		},
+		{
+			title:   "config file paths",
+			args:    []string{},
+			wantOut: "v1.1.0\nv1.0.1\n",
+			extraSetup: func(t *testing.T, repo *git.Repository, path string) {
+				testutils.CommitFile(t, repo, path, filepath.Join("a", "file"), "feat: add a", []byte("a\n"))
+				testutils.CommitFile(t, repo, path, filepath.Join("b", "file"), "fix: fix b", []byte("b\n"))
+				require.NoError(t, os.WriteFile(filepath.Join(path, "gotagger.json"), []byte(`{"paths": ["a", "b"]}`), 0600))
+			},
+		},
+		{
+			title:   "path filter glob without matches",
+			args:    []string{"-path", "c*"},
+			wantErr: "error: invalid path filter c*: no matching directories\n",
+			wantRc:  1,
+		},
+		{
+			title:   "path filter outside repository",
+			args:    []string{"-path", "../*"},
+			wantErr: "error: invalid path filter ../*: not within",
+			wantRc:  1,
+		},
 		{
 			title:   "path filter does not exist",
 			args:    []string{"-path", "missing"},
@@ -274,6 +500,133 @@ func TestGoTagger(t *testing.T) {
 	}
 }
 
+func TestGoTagger_goVersionLDFlags(t *testing.T) {
+	t.Parallel()
+
+	repo, path := testutils.NewGitRepo(t)
+	testutils.SimpleGitRepo(t, repo, path)
+	require.NoError(t, os.WriteFile(filepath.Join(path, "go.mod"), []byte("module foo\n"), 0600))
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+
+	g, stdout, stderr := newGotagger(path, []string{"go-version", "-ldflags", "-var", "AppVersion", "-import-path", "foo/internal/version"})
+	assert.Equal(t, 0, g.Run())
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, "-X foo/internal/version.AppVersion=v1.1.0 -X foo/internal/version.Commit="+head.Hash().String()+"\n", stdout.String())
+}
+
+func TestGoTagger_configSchema(t *testing.T) {
+	t.Parallel()
+
+	want, err := gotagger.ConfigSchema()
+	require.NoError(t, err)
+
+	g, stdout, stderr := newGotagger(t.TempDir(), []string{"config", "schema"})
+	assert.Equal(t, 0, g.Run())
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, string(want)+"\n", stdout.String())
+}
+
+func TestGoTagger_configShow(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("versionPrefix: release-\npushTag: true\nremoteName: upstream\n"), 0600))
+
+	t.Setenv("GOTAGGER_REMOTE", "env-remote")
+
+	g, stdout, stderr := newGotagger(path, []string{"config", "show", "-format", "json", "-dirty", "patch"})
+	require.Equal(t, 0, g.Run(), stderr.String())
+	assert.Empty(t, stderr.String())
+
+	var got struct {
+		ConfigFile string
+		Options    map[string]configOption
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+
+	assert.Equal(t, ".gotagger.yaml", filepath.Base(got.ConfigFile))
+	assert.Equal(t, configOption{"release-", sourceFile}, got.Options["versionPrefix"])
+	assert.Equal(t, configOption{true, sourceFile}, got.Options["pushTag"])
+	assert.Equal(t, configOption{true, sourceFile}, got.Options["createTag"])
+	assert.Equal(t, configOption{"env-remote", sourceEnv}, got.Options["remoteName"])
+	assert.Equal(t, configOption{"patch", sourceFlag}, got.Options["incrementDirtyWorktree"])
+	assert.Equal(t, configOption{false, sourceDefault}, got.Options["force"])
+
+	// text format
+	g, stdout, stderr = newGotagger(path, []string{"config", "show", "-prefix", "v"})
+	require.Equal(t, 0, g.Run(), stderr.String())
+
+	lines := strings.Split(stdout.String(), "\n")
+	if assert.Greater(t, len(lines), 3) {
+		assert.True(t, strings.HasPrefix(lines[0], "config file: "), lines[0])
+		assert.Equal(t, []string{"OPTION", "VALUE", "SOURCE"}, strings.Fields(lines[2]))
+	}
+
+	var fields [][]string
+	for _, line := range lines {
+		fields = append(fields, strings.Fields(line))
+	}
+	assert.Contains(t, fields, []string{"versionPrefix", `"v"`, sourceFlag})
+	assert.Contains(t, fields, []string{"remoteName", `"env-remote"`, sourceEnv})
+
+	// invalid format
+	g, _, stderr = newGotagger(path, []string{"config", "show", "-format", "xml"})
+	assert.Equal(t, 1, g.Run())
+	assert.Equal(t, "error: invalid format: xml\n", stderr.String())
+}
+
+func TestGoTagger_history(t *testing.T) {
+	t.Parallel()
+
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.CreateTag(t, repo, "v1.1.0")
+
+	g, stdout, stderr := newGotagger(path, []string{"history"})
+	require.Equal(t, 0, g.Run(), stderr.String())
+	assert.Empty(t, stderr.String())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if assert.Len(t, lines, 3) {
+		assert.Equal(t, []string{"PATH", "VERSION", "COMMIT", "DATE", "COMMITS"}, strings.Fields(lines[0]))
+
+		for i, want := range [][]string{{".", "v1.0.0", "2"}, {".", "v1.1.0", "1"}} {
+			fields := strings.Fields(lines[i+1])
+			if assert.Len(t, fields, 5) {
+				assert.Equal(t, want, []string{fields[0], fields[1], fields[4]})
+				assert.Regexp(t, `^[0-9a-f]{40}$`, fields[2])
+				assert.Regexp(t, `^\d{4}-\d{2}-\d{2}$`, fields[3])
+			}
+		}
+	}
+}
+
+func TestGoTagger_commandNamedPath(t *testing.T) {
+	t.Parallel()
+
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.CommitFile(t, repo, path, filepath.Join("history", "README.md"), "docs: add history", []byte("history"))
+
+	// commands win over directories with the same name
+	g, stdout, stderr := newGotagger(path, []string{"history"})
+	require.Equal(t, 0, g.Run(), stderr.String())
+	assert.Empty(t, stderr.String())
+	assert.True(t, strings.HasPrefix(stdout.String(), "PATH  VERSION"), stdout.String())
+
+	// paths named like a command are passed as ./PATH or after --
+	for _, args := range [][]string{{"./history"}, {"--", "history"}} {
+		g, stdout, stderr = newGotagger(path, args)
+		require.Equal(t, 0, g.Run(), stderr.String())
+		assert.Empty(t, stderr.String())
+		assert.Equal(t, "v1.1.0\n", stdout.String(), args)
+	}
+}
+
 func newGotagger(dir string, args []string) (*GoTagger, *bytes.Buffer, *bytes.Buffer) {
 	out := &bytes.Buffer{}
 	err := &bytes.Buffer{}
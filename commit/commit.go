@@ -0,0 +1,472 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commit parses and formats commit messages that follow the
+// Conventional Commits 1.0.0 specification:
+// https://www.conventionalcommits.org/en/v1.0.0/
+//
+// A message is a header, an optional body, and an optional footer block:
+//
+//	<type>[(<scope>)][!]: <description>
+//
+//	[body]
+//
+//	[footers]
+//
+// The footer block is the last paragraph of the message, and only if its first
+// line is a footer. Each footer is a token followed by either ": " or " #",
+// and a value that continues until the next footer. Tokens use hyphens in place
+// of whitespace, except for BREAKING CHANGE, and BREAKING-CHANGE is a synonym
+// of BREAKING CHANGE. Types, scopes, and tokens are returned as written;
+// types are case-insensitive, so compare them with strings.EqualFold.
+//
+// Parse also understands the headers of merge commits, Merge "<header>",
+// and of revert commits, Revert "<header>", created by git.
+// The merge commits of GitHub pull requests, Merge pull request #123 from <branch>,
+// and of GitLab merge requests and git, Merge branch '<branch>' into '<branch>',
+// are parsed from the title of the merged branch: the first line of the body.
+// Squash merges, <header> (#123), are parsed as is.
+//
+// Other commit message conventions implement the Parser interface:
+// Gitmoji parses gitmoji messages, and Regex parses messages
+// with a user-defined regular expression.
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Footer separators.
+const (
+	// ColonSeparator separates the token and value of footers like "Refs: #123".
+	ColonSeparator = ": "
+
+	// HashSeparator separates the token and value of footers like "Fixes #123".
+	HashSeparator = " #"
+)
+
+// Breaking change footer tokens. They must be uppercase.
+const (
+	BreakingChange       = "BREAKING CHANGE"
+	BreakingChangeHyphen = "BREAKING-CHANGE"
+)
+
+// ErrEmptyMessage is returned by Parse for empty commit messages.
+var ErrEmptyMessage = errors.New("empty commit message")
+
+var (
+	mergeRe       = regexp.MustCompile(`^(Merge ")(.*)"$`)
+	branchMergeRe = regexp.MustCompile(`^Merge (?:pull request #(\d+) from \S+|(?:remote-tracking )?branch '[^']+'(?: of \S+)?(?: into \S+)?)$`)
+	revertRe      = regexp.MustCompile(`^(Revert\s")([\s\S]+)"\s*This reverts commit (\w+)\.`)
+
+	// pull request references of squash merges and GitLab merge requests
+	pullRequestRe  = regexp.MustCompile(` \(#(\d+)\)$`)
+	mergeRequestRe = regexp.MustCompile(`^See merge request \S*!(\d+)$`)
+)
+
+// Commit represents the parsed data from a conventional commit message.
+type Commit struct {
+	// Type is the type of the commit, such as feat or fix.
+	Type string
+
+	// Scope is the optional scope of the commit, without parentheses.
+	Scope string
+
+	// Subject is the description in the header.
+	Subject string
+
+	// Body is the free-form text between the header and the footers.
+	Body string
+
+	// Breaking is true if the header has a "!",
+	// or if there is a BREAKING CHANGE footer.
+	Breaking bool
+
+	// Header is the first line of the message,
+	// the quoted header of a merge or revert commit,
+	// or the title of a merged pull request.
+	Header string
+
+	// Footers are the footers of the message, in order.
+	Footers []Footer
+
+	// Merge is true for merge commits.
+	Merge bool
+
+	// PullRequest is the number of the pull request or merge request
+	// that the commit merged, if the message refers to one.
+	PullRequest string
+
+	// Revert is what a revert commit reverts.
+	Revert Revert
+}
+
+// Message returns the message of c: the header, the body, and the footers,
+// separated by blank lines.
+func (c Commit) Message() string {
+	message := c.Header
+	if c.Body != "" {
+		message += "\n\n" + c.Body
+	}
+	var footer string
+	for _, f := range c.Footers {
+		footer += "\n" + f.String()
+	}
+
+	if footer != "" {
+		message += "\n" + footer
+	}
+
+	return message
+}
+
+// Footer represents a conventional commit footer, which roughly corresponds to a
+// git trailer: Foo-bar: some text, or Fixes #123.
+type Footer struct {
+	// Title is the token of the footer, such as Refs or BREAKING CHANGE.
+	Title string
+
+	// Text is the value of the footer, which may span several lines.
+	Text string
+
+	// Separator is ColonSeparator or HashSeparator.
+	// Defaults to ColonSeparator.
+	Separator string
+}
+
+// String returns the footer as it appears in a commit message.
+func (f Footer) String() string {
+	sep := f.Separator
+	if sep == "" {
+		sep = ColonSeparator
+	}
+
+	return f.Title + sep + f.Text
+}
+
+// IsBreaking returns true if f is a BREAKING CHANGE footer.
+func (f Footer) IsBreaking() bool {
+	return f.Title == BreakingChange || f.Title == BreakingChangeHyphen
+}
+
+// Revert represents what this commmit reverts.
+type Revert struct {
+	// Header is the header of the reverted commit.
+	Header string
+
+	// Hash is the hash of the reverted commit.
+	Hash string
+}
+
+// SyntaxError describes why the header of a commit message
+// is not a conventional commit header.
+type SyntaxError struct {
+	// Header is the header that failed to parse.
+	Header string
+
+	// Column is the position in the first line of the message,
+	// in bytes starting at 1, where the error was found.
+	// For the title of a merged pull request, Column is the position in the title.
+	Column int
+
+	// Msg describes the error.
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid header %q: column %d: %s", e.Header, e.Column, e.Msg)
+}
+
+// Parse parses a commit message and returns a conventional commit.
+//
+// If the message does not follow the format, then Parse returns the zero Commit
+// and ErrEmptyMessage or a *SyntaxError that explains why.
+func Parse(message string) (Commit, error) {
+	if message == "" {
+		return Commit{}, ErrEmptyMessage
+	}
+
+	lines := strings.Split(message, "\n")
+	header, lines := lines[0], lines[1:]
+
+	// the column of the header in the first line
+	offset := 0
+
+	// Is this a merge commit
+	var merge bool
+	var pullRequest string
+	if m := mergeRe.FindStringSubmatch(header); len(m) > 0 {
+		merge = true
+		offset = len(m[1])
+		header = m[2]
+	} else if m := branchMergeRe.FindStringSubmatch(header); len(m) > 0 {
+		// the title of the merged branch is the first line of the body
+		title, rest := mergeTitle(lines)
+		if title == "" {
+			return Commit{}, &SyntaxError{Header: header, Column: len(header) + 1, Msg: "missing the title of the merged branch"}
+		}
+
+		merge = true
+		pullRequest = m[1]
+		header, lines = title, rest
+	}
+
+	// is this a revert commit
+	var revert Revert
+	if m := revertRe.FindStringSubmatch(message); len(m) > 0 {
+		revert.Header = m[2]
+		revert.Hash = m[3]
+		offset = len(m[1])
+		header = m[2]
+	}
+
+	typ, scope, breaking, subject, err := parseHeader(header)
+	if err != nil {
+		err.Header = header
+		err.Column += offset
+		return Commit{}, err
+	}
+
+	if pullRequest == "" {
+		pullRequest = findPullRequest(header, lines)
+	}
+
+	body, footers := parseMessageBody(lines)
+	for _, f := range footers {
+		breaking = breaking || f.IsBreaking()
+	}
+
+	return Commit{
+		Type:        typ,
+		Scope:       scope,
+		Subject:     strings.TrimSpace(subject),
+		Breaking:    breaking,
+		Body:        body,
+		Header:      header,
+		Footers:     footers,
+		Merge:       merge,
+		PullRequest: pullRequest,
+		Revert:      revert,
+	}, nil
+}
+
+// ParseFooters returns the footers of message, also known as git trailers.
+//
+// Unlike Parse, ParseFooters does not parse the header, so it returns the footers
+// of messages that do not follow the convention, such as "Bump foo from 1 to 2".
+func ParseFooters(message string) []Footer {
+	lines := strings.Split(message, "\n")
+	_, footers := parseMessageBody(lines[1:])
+	return footers
+}
+
+// mergeTitle returns the first line of the body of a merge commit,
+// and the lines that follow it.
+func mergeTitle(lines []string) (title string, rest []string) {
+	for i, line := range lines {
+		if !isBlank(line) {
+			return strings.TrimSpace(line), lines[i+1:]
+		}
+	}
+
+	return "", nil
+}
+
+// findPullRequest returns the number of the pull request in the (#123) suffix
+// of a squash merge header, or of the merge request in the
+// "See merge request group/project!123" line of a GitLab merge commit.
+func findPullRequest(header string, lines []string) string {
+	if m := pullRequestRe.FindStringSubmatch(header); len(m) > 0 {
+		return m[1]
+	}
+
+	for _, line := range lines {
+		if m := mergeRequestRe.FindStringSubmatch(strings.TrimSpace(line)); len(m) > 0 {
+			return m[1]
+		}
+	}
+
+	return ""
+}
+
+// Format returns the commit message of c.
+//
+// Unlike Message, Format builds the header from the Type, Scope, Subject, and
+// Breaking fields, so it can format commits that were not parsed.
+// The header of a breaking commit without a BREAKING CHANGE footer
+// has a "!" after the type and scope.
+// Merge, PullRequest, and Revert are ignored.
+//
+// For a commit c with valid fields, Parse(Format(c)) returns c,
+// with the Header set to the formatted header,
+// and the PullRequest set from a (#123) suffix of the Subject.
+func Format(c Commit) string {
+	c.Header = formatHeader(c)
+	return c.Message()
+}
+
+// formatHeader returns the header of c.
+func formatHeader(c Commit) string {
+	header := c.Type
+	if c.Scope != "" {
+		header += "(" + c.Scope + ")"
+	}
+
+	if c.Breaking {
+		footer := false
+		for _, f := range c.Footers {
+			footer = footer || f.IsBreaking()
+		}
+
+		if !footer {
+			header += "!"
+		}
+	}
+
+	return header + ": " + c.Subject
+}
+
+// parseHeader parses the header of a commit message:
+//
+//	<type>[(<scope>)][!]: <description>
+//
+// The type is any text without whitespace, parentheses, colons, or
+// exclamation marks. The scope is any text without parentheses.
+func parseHeader(header string) (typ, scope string, breaking bool, subject string, err *SyntaxError) {
+	syntaxError := func(pos int, msg string) *SyntaxError {
+		return &SyntaxError{Column: pos + 1, Msg: msg}
+	}
+
+	n := strings.IndexFunc(header, func(r rune) bool {
+		return r == '(' || r == ')' || r == ':' || r == '!' || unicode.IsSpace(r)
+	})
+	switch {
+	case n == 0:
+		err = syntaxError(0, "missing type")
+		return
+	case n < 0:
+		err = syntaxError(len(header), `missing ": " after the type`)
+		return
+	}
+	typ, pos := header[:n], n
+
+	if header[pos] == '(' {
+		end := strings.IndexAny(header[pos+1:], "()")
+		switch {
+		case end < 0:
+			err = syntaxError(pos, "missing ) after the scope")
+			return
+		case end == 0 && header[pos+1] == ')':
+			err = syntaxError(pos+1, "empty scope")
+			return
+		case header[pos+1+end] == '(':
+			err = syntaxError(pos+1+end, "scope contains (")
+			return
+		}
+		scope, pos = header[pos+1:pos+1+end], pos+end+2
+	}
+
+	if strings.HasPrefix(header[pos:], "!") {
+		breaking, pos = true, pos+1
+	}
+
+	rest := header[pos:]
+	switch {
+	case strings.HasPrefix(rest, ":") && !strings.HasPrefix(rest, ": "):
+		err = syntaxError(pos+1, `missing space after ":"`)
+		return
+	case !strings.HasPrefix(rest, ": "):
+		if scope == "" && !breaking {
+			err = syntaxError(pos, `type must be followed by a scope, "!", or ": "`)
+		} else {
+			err = syntaxError(pos, `missing ": " before the description`)
+		}
+		return
+	case len(rest) == len(": "):
+		err = syntaxError(len(header), "missing description")
+		return
+	}
+	subject = rest[len(": "):]
+
+	return typ, scope, breaking, subject, nil
+}
+
+// parseMessageBody splits the lines that follow the header into the body
+// and the footers.
+//
+// The footers are the last paragraph, if its first line is a footer.
+// Lines that are not footers continue the value of the previous footer.
+func parseMessageBody(lines []string) (body string, footers []Footer) {
+	// ignore trailing blank lines
+	for len(lines) > 0 && isBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	// find the start of the last paragraph
+	start := 0
+	for i, line := range lines {
+		if isBlank(line) {
+			start = i + 1
+		}
+	}
+
+	if start < len(lines) {
+		if _, ok := parseFooter(lines[start]); ok {
+			for _, line := range lines[start:] {
+				if f, ok := parseFooter(line); ok {
+					footers = append(footers, f)
+					continue
+				}
+
+				footers[len(footers)-1].Text += "\n" + line
+			}
+
+			lines = lines[:start]
+		}
+	}
+
+	body = strings.TrimSpace(strings.Join(lines, "\n"))
+
+	return
+}
+
+// parseFooter parses the first line of a footer:
+//
+//	<token>: <value>
+//	<token> #<value>
+//
+// The token is BREAKING CHANGE,
+// or any text without whitespace, parentheses, colons, or hashes.
+func parseFooter(line string) (f Footer, ok bool) {
+	tokens := []string{BreakingChange}
+	n := strings.IndexFunc(line, func(r rune) bool {
+		return r == '(' || r == ')' || r == ':' || r == '#' || unicode.IsSpace(r)
+	})
+	if n > 0 {
+		tokens = append(tokens, line[:n])
+	}
+
+	for _, token := range tokens {
+		if !strings.HasPrefix(line, token) {
+			continue
+		}
+
+		rest := line[len(token):]
+		for _, sep := range []string{ColonSeparator, HashSeparator} {
+			if strings.HasPrefix(rest, sep) {
+				return Footer{Title: token, Text: rest[len(sep):], Separator: sep}, true
+			}
+		}
+	}
+
+	return
+}
+
+// isBlank returns true if line only contains whitespace.
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
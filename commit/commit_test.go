@@ -0,0 +1,541 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package commit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"pgregory.net/rapid"
+)
+
+func TestCommit_Message(t *testing.T) {
+	tests := []struct {
+		commit Commit
+		want   string
+	}{
+		{
+			commit: Commit{Header: "header"},
+			want:   "header",
+		},
+		{
+			commit: Commit{Header: "header", Body: "body"},
+			want:   "header\n\nbody",
+		},
+		{
+			commit: Commit{Header: "header", Footers: []Footer{{Title: "title", Text: "text"}}},
+			want:   "header\n\ntitle: text",
+		},
+		{
+			commit: Commit{Header: "header", Body: "body", Footers: []Footer{{Title: "title", Text: "text"}}},
+			want:   "header\n\nbody\n\ntitle: text",
+		},
+		{
+			commit: Commit{Header: "header", Footers: []Footer{{Title: "Fixes", Text: "123", Separator: HashSeparator}}},
+			want:   "header\n\nFixes #123",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			message := tt.commit.Message()
+			assert.Equal(t, tt.want, message)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		ctype := rapid.StringMatching(`^\w*$`).Draw(t, "type")
+		scope := rapid.StringMatching(`[\w$.\-*/ ]*`).Draw(t, "scope")
+		isBreaking := rapid.Bool().Draw(t, "breaking")
+		subject := rapid.StringMatching(`^.*$`).Filter(noPullRequest).Draw(t, "subject")
+		body := rapid.Map(rapid.SliceOf(
+			rapid.String().Filter(func(s string) bool { return !strings.Contains(s, ": ") && !strings.Contains(s, " #") }),
+		), func(s []string) string {
+			return strings.Join(s, "\n")
+		}).Draw(t, "body")
+
+		header := ctype
+		if scope != "" {
+			header += "(" + scope + ")"
+		}
+		if isBreaking {
+			header += "!"
+		}
+		if subject != "" {
+			header += ": " + subject
+		}
+		input := header
+		if body != "" {
+			input += "\n\n" + body
+		}
+		var c Commit
+		if ctype != "" && subject != "" {
+			c = Commit{
+				Type:     ctype,
+				Scope:    scope,
+				Subject:  strings.TrimSpace(subject),
+				Body:     strings.TrimSpace(body),
+				Breaking: isBreaking,
+				Header:   header,
+			}
+		}
+		got, err := Parse(input)
+		assert.Equal(t, c, got)
+		if c.Type == "" {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	})
+}
+
+// noPullRequest returns true if s does not end with a pull request reference,
+// so that Parse does not set the PullRequest of a generated commit.
+func noPullRequest(s string) bool {
+	return !pullRequestRe.MatchString(s)
+}
+
+func TestParse_empty(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		input := rapid.StringMatching(`^\s*`).Draw(t, "input")
+		got, err := Parse(input)
+		assert.Equal(t, Commit{}, got)
+		assert.Error(t, err)
+	})
+}
+
+func TestParse_merge(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		ctype := rapid.StringMatching(`^\w+$`).Draw(t, "type")
+		scope := rapid.StringMatching(`^\w*$`).Draw(t, "scope")
+		isBreaking := rapid.Bool().Draw(t, "breaking")
+		subject := rapid.StringMatching(`^.+$`).Filter(noPullRequest).Draw(t, "subject")
+		body := rapid.Map(
+			rapid.SliceOf(
+				rapid.String().Filter(func(s string) bool { return !strings.Contains(s, ": ") && !strings.Contains(s, " #") }),
+			),
+			func(s []string) string {
+				return strings.Join(s, "\n")
+			},
+		).Draw(t, "body")
+
+		header := ctype
+		if scope != "" {
+			header += "(" + scope + ")"
+		}
+		if isBreaking {
+			header += "!"
+		}
+		header += ": " + subject
+
+		want := Commit{
+			Type:     ctype,
+			Scope:    scope,
+			Subject:  strings.TrimSpace(subject),
+			Body:     strings.TrimSpace(body),
+			Breaking: isBreaking,
+			Header:   header,
+			Merge:    true,
+		}
+
+		input := "Merge \"" + header + "\"" + "\n\n" + body
+		got, err := Parse(input)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestParse_revert(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		ctype := rapid.StringMatching(`^\w+$`).Draw(t, "type")
+		scope := rapid.StringMatching(`^\w*$`).Draw(t, "scope")
+		isBreaking := rapid.Bool().Draw(t, "breaking")
+		subject := rapid.StringMatching(`^.+$`).Filter(noPullRequest).Draw(t, "subject")
+		hash := rapid.StringMatching(`^\w*$`).Draw(t, "hash")
+
+		header := ctype
+		if scope != "" {
+			header += "(" + scope + ")"
+		}
+		if isBreaking {
+			header += "!"
+		}
+		header += ": " + subject
+
+		input := "Revert \"" + header + "\"\n\nThis reverts commit " + hash + "."
+		var c Commit
+		if hash != "" {
+			c = Commit{
+				Type:     ctype,
+				Scope:    scope,
+				Subject:  strings.TrimSpace(subject),
+				Body:     strings.TrimSpace("This reverts commit " + hash + "."),
+				Breaking: isBreaking,
+				Header:   header,
+				Revert: Revert{
+					Header: header,
+					Hash:   hash,
+				},
+			}
+		}
+		got, _ := Parse(input)
+		assert.Equal(t, c, got)
+	})
+}
+
+func TestParse_platformMerges(t *testing.T) {
+	tests := []struct {
+		title   string
+		message string
+		want    Commit
+		wantErr string
+	}{
+		{
+			title:   "github squash merge",
+			message: "feat: add a thing (#123)\n\n* wip\n* more wip",
+			want: Commit{
+				Type:        "feat",
+				Subject:     "add a thing (#123)",
+				Body:        "* wip\n* more wip",
+				Header:      "feat: add a thing (#123)",
+				PullRequest: "123",
+			},
+		},
+		{
+			title:   "github merge",
+			message: "Merge pull request #42 from octocat/feature\n\nfeat(api)!: add a thing\n\nSome details.",
+			want: Commit{
+				Type:        "feat",
+				Scope:       "api",
+				Subject:     "add a thing",
+				Body:        "Some details.",
+				Breaking:    true,
+				Header:      "feat(api)!: add a thing",
+				Merge:       true,
+				PullRequest: "42",
+			},
+		},
+		{
+			title:   "gitlab merge",
+			message: "Merge branch 'feature' into 'main'\n\nfix: handle nil\n\nSee merge request group/project!7",
+			want: Commit{
+				Type:        "fix",
+				Subject:     "handle nil",
+				Body:        "See merge request group/project!7",
+				Header:      "fix: handle nil",
+				Merge:       true,
+				PullRequest: "7",
+			},
+		},
+		{
+			title:   "git merge with a title",
+			message: "Merge branch 'feature'\n\nfeat: add a thing",
+			want:    Commit{Type: "feat", Subject: "add a thing", Header: "feat: add a thing", Merge: true},
+		},
+		{
+			title:   "git merge without a title",
+			message: "Merge remote-tracking branch 'origin/main' into main",
+			wantErr: `invalid header "Merge remote-tracking branch 'origin/main' into main": column 53: missing the title of the merged branch`,
+		},
+		{
+			title:   "github merge with an invalid title",
+			message: "Merge pull request #42 from octocat/feature\n\nAdd a thing",
+			wantErr: `invalid header "Add a thing": column 4: type must be followed by a scope, "!", or ": "`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			got, err := Parse(tt.message)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParse_arbitrary(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		input := rapid.String().Draw(t, "input")
+		got, err := Parse(input)
+
+		// a message without a ": " separator is not a conventional commit
+		if !strings.Contains(input, ": ") {
+			assert.Equal(t, Commit{}, got)
+			assert.Error(t, err)
+			return
+		}
+
+		// anything else is either not a conventional commit,
+		// or has a type and a header from the first line
+		if err == nil {
+			assert.NotEmpty(t, got.Type)
+			assert.True(t, strings.HasPrefix(got.Header, got.Type), "header %q does not start with type %q", got.Header, got.Type)
+			assert.NotContains(t, got.Header, "\n")
+		} else {
+			assert.Equal(t, Commit{}, got)
+		}
+	})
+}
+
+func TestParse_footer(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		header := "feat: some feature"
+		body := "some text\nthat people wrote"
+		bFooterTitle := rapid.SampledFrom([]string{"", BreakingChange, BreakingChangeHyphen, "Breaking-Change", "breaking change"}).Draw(t, "bFooterTitle")
+		bFooterSep := rapid.SampledFrom([]string{ColonSeparator, HashSeparator}).Draw(t, "bFooterSep")
+		bFooterText := rapid.StringMatching(`^[^\n]*$`).Draw(t, "bFooterText")
+		footerTitle := rapid.StringMatching(`^([[:alnum:]][-\w]*)?$`).Draw(t, "footerTitle")
+		footerSep := rapid.SampledFrom([]string{ColonSeparator, HashSeparator}).Draw(t, "footerSep")
+		footerText := rapid.Map(
+			rapid.SliceOf(
+				rapid.
+					String().
+					Filter(func(s string) bool {
+						return strings.TrimSpace(s) != "" && !strings.Contains(s, "\n") && !strings.Contains(s, ": ") && !strings.Contains(s, " #")
+					})),
+			func(s []string) string { return strings.Join(s, "\n") },
+		).Draw(t, "footerText")
+
+		input := header + "\n\n" + body + "\n\n" + bFooterTitle + bFooterSep + bFooterText
+		if footerTitle != "" {
+			input += "\n" + footerTitle + footerSep + footerText
+		}
+
+		// only the uppercase tokens are breaking changes,
+		// and tokens with spaces are not footers
+		isBreaking := bFooterTitle == BreakingChange || bFooterTitle == BreakingChangeHyphen
+		isFooter := bFooterTitle != "" && !strings.Contains(bFooterTitle, " ") || isBreaking
+
+		c, err := Parse(input)
+		require.NoError(t, err)
+
+		var want []Footer
+		if isFooter {
+			want = append(want, Footer{Title: bFooterTitle, Text: bFooterText, Separator: bFooterSep})
+			if footerTitle != "" {
+				want = append(want, Footer{Title: footerTitle, Text: footerText, Separator: footerSep})
+			}
+		}
+		assert.Equal(t, want, c.Footers)
+		assert.Equal(t, isBreaking, c.Breaking)
+
+		if isFooter {
+			assert.Equal(t, body, c.Body)
+		}
+	})
+}
+
+func TestParse_conformance(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "conformance.yaml"))
+	require.NoError(t, err)
+
+	var corpus []struct {
+		Name    string `yaml:"name"`
+		Message string `yaml:"message"`
+		Want    struct {
+			Type     string `yaml:"type"`
+			Scope    string `yaml:"scope"`
+			Subject  string `yaml:"subject"`
+			Body     string `yaml:"body"`
+			Breaking bool   `yaml:"breaking"`
+			Footers  []struct {
+				Token     string `yaml:"token"`
+				Separator string `yaml:"separator"`
+				Value     string `yaml:"value"`
+			} `yaml:"footers"`
+		} `yaml:"want"`
+		Error string `yaml:"error"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &corpus))
+
+	for _, tt := range corpus {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse(tt.Message)
+			if tt.Error != "" {
+				assert.Equal(t, Commit{}, got)
+				assert.EqualError(t, err, tt.Error)
+				return
+			}
+			require.NoError(t, err)
+
+			var footers []Footer
+			for _, f := range tt.Want.Footers {
+				footers = append(footers, Footer{Title: f.Token, Text: f.Value, Separator: f.Separator})
+			}
+
+			assert.Equal(t, tt.Want.Type, got.Type, "type")
+			assert.Equal(t, tt.Want.Scope, got.Scope, "scope")
+			assert.Equal(t, tt.Want.Subject, got.Subject, "subject")
+			assert.Equal(t, tt.Want.Body, got.Body, "body")
+			assert.Equal(t, tt.Want.Breaking, got.Breaking, "breaking")
+			assert.Equal(t, footers, got.Footers, "footers")
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	_, err := Parse("")
+	assert.ErrorIs(t, err, ErrEmptyMessage)
+
+	_, err = Parse("feat:add something\n\nbody")
+	var syntaxErr *SyntaxError
+	if assert.ErrorAs(t, err, &syntaxErr) {
+		assert.Equal(t, &SyntaxError{Header: "feat:add something", Column: 6, Msg: `missing space after ":"`}, syntaxErr)
+	}
+
+	// columns are relative to the first line of merge commits
+	_, err = Parse(`Merge "feat(): add something"`)
+	if assert.ErrorAs(t, err, &syntaxErr) {
+		assert.Equal(t, 13, syntaxErr.Column)
+		assert.Equal(t, "empty scope", syntaxErr.Msg)
+	}
+}
+
+func TestParseFooters(t *testing.T) {
+	tests := []struct {
+		title   string
+		message string
+		want    []Footer
+	}{
+		{
+			title:   "empty message",
+			message: "",
+		},
+		{
+			title:   "no footers",
+			message: "Bump foo from 1 to 2\n\nBumps foo.",
+		},
+		{
+			title:   "not conventional",
+			message: "Bump foo from 1 to 2\n\nRelease-Ignore: true",
+			want:    []Footer{{Title: "Release-Ignore", Separator: ColonSeparator, Text: "true"}},
+		},
+		{
+			title:   "several footers after the body",
+			message: "Bump foo from 1 to 2\n\nBumps foo.\n\nRelease-Ignore: true\nSigned-off-by: Bot <bot@example.com>",
+			want: []Footer{
+				{Title: "Release-Ignore", Separator: ColonSeparator, Text: "true"},
+				{Title: "Signed-off-by", Separator: ColonSeparator, Text: "Bot <bot@example.com>"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseFooters(tt.message))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		title  string
+		commit Commit
+		want   string
+	}{
+		{
+			title:  "header",
+			commit: Commit{Type: "feat", Subject: "add something"},
+			want:   "feat: add something",
+		},
+		{
+			title:  "scope and body",
+			commit: Commit{Type: "fix", Scope: "api", Subject: "handle nil", Body: "body"},
+			want:   "fix(api): handle nil\n\nbody",
+		},
+		{
+			title:  "breaking",
+			commit: Commit{Type: "feat", Subject: "remove the old API", Breaking: true},
+			want:   "feat!: remove the old API",
+		},
+		{
+			title: "breaking footer",
+			commit: Commit{
+				Type:     "feat",
+				Subject:  "remove the old API",
+				Breaking: true,
+				Footers:  []Footer{{Title: BreakingChange, Text: "the old API is gone", Separator: ColonSeparator}},
+			},
+			want: "feat: remove the old API\n\nBREAKING CHANGE: the old API is gone",
+		},
+		{
+			title: "header is ignored",
+			commit: Commit{
+				Type:    "feat",
+				Subject: "new subject",
+				Header:  "feat: old subject",
+				Footers: []Footer{{Title: "Fixes", Text: "123", Separator: HashSeparator}},
+			},
+			want: "feat: new subject\n\nFixes #123",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Format(tt.commit))
+		})
+	}
+}
+
+func TestFormat_roundTrip(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		line := func(s string) bool {
+			return strings.TrimSpace(s) != "" && !strings.Contains(s, ": ") && !strings.Contains(s, " #")
+		}
+
+		c := Commit{
+			Type:     rapid.StringMatching(`^[a-z]+$`).Draw(t, "type"),
+			Scope:    rapid.StringMatching(`^[\w$.\-*/ ]*$`).Draw(t, "scope"),
+			Subject:  rapid.StringMatching(`^[^\n]+$`).Filter(func(s string) bool { return strings.TrimSpace(s) == s && noPullRequest(s) }).Draw(t, "subject"),
+			Breaking: rapid.Bool().Draw(t, "breaking"),
+			Body: rapid.Map(
+				rapid.SliceOf(rapid.StringMatching(`^[^\n]*$`).Filter(line)),
+				func(s []string) string { return strings.Join(s, "\n") },
+			).Filter(func(s string) bool { return strings.TrimSpace(s) == s }).Draw(t, "body"),
+		}
+
+		footers := rapid.SliceOf(rapid.Custom(func(t *rapid.T) Footer {
+			return Footer{
+				Title:     rapid.SampledFrom([]string{"Refs", "Fixes", "Reviewed-by", BreakingChange, BreakingChangeHyphen}).Draw(t, "title"),
+				Text:      rapid.StringMatching(`^[^\n]*$`).Filter(line).Draw(t, "text"),
+				Separator: rapid.SampledFrom([]string{ColonSeparator, HashSeparator}).Draw(t, "separator"),
+			}
+		})).Draw(t, "footers")
+		for _, f := range footers {
+			c.Footers = append(c.Footers, f)
+			c.Breaking = c.Breaking || f.IsBreaking()
+		}
+
+		message := Format(c)
+		got, err := Parse(message)
+		require.NoError(t, err, message)
+
+		c.Header = strings.SplitN(message, "\n", 2)[0]
+		assert.Equal(t, c, got)
+		assert.Equal(t, message, got.Message())
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Parser parses commit messages that follow a commit message convention.
+type Parser interface {
+	// Name returns the name of the convention.
+	Name() string
+
+	// Parse parses message. If message does not follow the convention,
+	// then Parse returns the zero Commit and an error that explains why.
+	Parse(message string) (Commit, error)
+}
+
+// Names of the built-in parsers.
+const (
+	ConventionalParser = "conventional"
+	GitmojiParser      = "gitmoji"
+	RegexParser        = "regex"
+)
+
+// Conventional parses Conventional Commits. See Parse.
+type Conventional struct{}
+
+// Name returns "conventional".
+func (Conventional) Name() string { return ConventionalParser }
+
+// Parse parses a conventional commit message.
+func (Conventional) Parse(message string) (Commit, error) {
+	return Parse(message)
+}
+
+// DefaultGitmojiTypes maps the gitmojis that correspond to the commit types
+// gotagger understands. Other gitmojis have no type.
+var DefaultGitmojiTypes = map[string]string{
+	"✨":                     "feat",
+	":sparkles:":            "feat",
+	"💥":                     "feat",
+	":boom:":                "feat",
+	"🐛":                     "fix",
+	":bug:":                 "fix",
+	"🚑":                     "fix",
+	":ambulance:":           "fix",
+	"🔒":                     "fix",
+	":lock:":                "fix",
+	"⚡":                     "perf",
+	":zap:":                 "perf",
+	"♻":                     "refactor",
+	":recycle:":             "refactor",
+	"📝":                     "docs",
+	":memo:":                "docs",
+	"✅":                     "test",
+	":white_check_mark:":    "test",
+	"👷":                     "ci",
+	":construction_worker:": "ci",
+	"🔖":                     "release",
+	":bookmark:":            "release",
+}
+
+// gitmojiBreaking are the gitmojis of breaking changes.
+var gitmojiBreaking = map[string]bool{"💥": true, ":boom:": true}
+
+var gitmojiShortcodeRe = regexp.MustCompile(`^:[a-z0-9_+-]+:`)
+
+// Gitmoji parses gitmoji commit messages: https://gitmoji.dev
+//
+//	<gitmoji> [(<scope>)][:] <description>
+//
+// The gitmoji is an emoji, such as ✨, or its shortcode, such as :sparkles:.
+// The body and footers follow the Conventional Commits specification,
+// and 💥 or a BREAKING CHANGE footer marks a breaking change.
+type Gitmoji struct {
+	// Types maps gitmojis to commit types.
+	// Gitmojis that are not in Types use DefaultGitmojiTypes.
+	Types map[string]string
+}
+
+// Name returns "gitmoji".
+func (g *Gitmoji) Name() string { return GitmojiParser }
+
+// Parse parses a gitmoji commit message.
+func (g *Gitmoji) Parse(message string) (Commit, error) {
+	if message == "" {
+		return Commit{}, ErrEmptyMessage
+	}
+
+	lines := strings.Split(message, "\n")
+	header := lines[0]
+
+	emoji := gitmojiShortcodeRe.FindString(header)
+	if emoji == "" {
+		// an emoji is every rune before the first space, parenthesis, or colon
+		emoji = header
+		if n := strings.IndexAny(header, " (:"); n >= 0 {
+			emoji = header[:n]
+		}
+
+		if emoji == "" || !isEmoji(emoji) {
+			return Commit{}, &SyntaxError{Header: header, Column: 1, Msg: "missing gitmoji"}
+		}
+	}
+
+	typ, ok := g.lookup(emoji)
+	if !ok {
+		return Commit{}, &SyntaxError{Header: header, Column: 1, Msg: fmt.Sprintf("unknown gitmoji %s", emoji)}
+	}
+
+	pos := len(emoji)
+	rest := strings.TrimLeft(header[pos:], " ")
+	pos = len(header) - len(rest)
+
+	var scope string
+	if strings.HasPrefix(rest, "(") {
+		end := strings.IndexByte(rest, ')')
+		if end <= 1 {
+			return Commit{}, &SyntaxError{Header: header, Column: pos + 1, Msg: "invalid scope"}
+		}
+		scope, rest = rest[1:end], rest[end+1:]
+	}
+
+	rest = strings.TrimPrefix(rest, ":")
+	subject := strings.TrimSpace(rest)
+	if subject == "" {
+		return Commit{}, &SyntaxError{Header: header, Column: len(header) + 1, Msg: "missing description"}
+	}
+
+	body, footers := parseMessageBody(lines[1:])
+	breaking := gitmojiBreaking[stripVariationSelectors(emoji)]
+	for _, f := range footers {
+		breaking = breaking || f.IsBreaking()
+	}
+
+	return Commit{
+		Type:     typ,
+		Scope:    scope,
+		Subject:  subject,
+		Body:     body,
+		Breaking: breaking,
+		Header:   header,
+		Footers:  footers,
+	}, nil
+}
+
+// lookup returns the commit type of emoji.
+func (g *Gitmoji) lookup(emoji string) (string, bool) {
+	emoji = stripVariationSelectors(emoji)
+	for _, types := range []map[string]string{g.Types, DefaultGitmojiTypes} {
+		for k, typ := range types {
+			if stripVariationSelectors(k) == emoji {
+				return typ, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// stripVariationSelectors removes emoji variation selectors from s,
+// so that ⚡️ and ⚡ are the same gitmoji.
+func stripVariationSelectors(s string) string {
+	return strings.ReplaceAll(s, "\ufe0f", "")
+}
+
+// isEmoji returns true if s does not contain any ASCII characters.
+func isEmoji(s string) bool {
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Regex parses commit messages with a regular expression.
+//
+// The pattern is matched against the whole message,
+// and must have a named group called type.
+// The optional named groups scope and subject capture the scope and description,
+// and a commit is breaking if the optional group breaking matches any text,
+// so that patterns like `(?s)^(?P<type>\w+): (?P<subject>[^\n]*)(?P<breaking>.*\nBREAKING: .*)?`
+// find breaking changes in the body.
+// The description defaults to the first line of the message.
+// The body and footers follow the Conventional Commits specification,
+// and a BREAKING CHANGE footer also marks a breaking change.
+type Regex struct {
+	pattern *regexp.Regexp
+}
+
+// NewRegex returns a Regex parser for pattern.
+// It returns an error if pattern is invalid, or does not have a type group.
+func NewRegex(pattern string) (*Regex, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if re.SubexpIndex("type") < 0 {
+		return nil, errors.New("pattern must have a named group called type")
+	}
+
+	return &Regex{pattern: re}, nil
+}
+
+// Name returns "regex".
+func (r *Regex) Name() string { return RegexParser }
+
+// Pattern returns the regular expression of r.
+func (r *Regex) Pattern() string { return r.pattern.String() }
+
+// Parse parses a commit message that matches the pattern of r.
+func (r *Regex) Parse(message string) (Commit, error) {
+	if message == "" {
+		return Commit{}, ErrEmptyMessage
+	}
+
+	lines := strings.Split(message, "\n")
+	header := lines[0]
+
+	m := r.pattern.FindStringSubmatch(message)
+	if m == nil {
+		return Commit{}, fmt.Errorf("message does not match %s", r.pattern)
+	}
+
+	group := func(name string) string {
+		if i := r.pattern.SubexpIndex(name); i >= 0 {
+			return m[i]
+		}
+
+		return ""
+	}
+
+	typ := group("type")
+	if typ == "" {
+		return Commit{}, fmt.Errorf("message does not have a type: %s", header)
+	}
+
+	subject := group("subject")
+	if r.pattern.SubexpIndex("subject") < 0 {
+		subject = header
+	}
+
+	body, footers := parseMessageBody(lines[1:])
+	breaking := group("breaking") != ""
+	for _, f := range footers {
+		breaking = breaking || f.IsBreaking()
+	}
+
+	return Commit{
+		Type:     typ,
+		Scope:    group("scope"),
+		Subject:  strings.TrimSpace(subject),
+		Body:     body,
+		Breaking: breaking,
+		Header:   header,
+		Footers:  footers,
+	}, nil
+}
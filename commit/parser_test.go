@@ -0,0 +1,192 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package commit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitmoji_Parse(t *testing.T) {
+	tests := []struct {
+		title   string
+		types   map[string]string
+		message string
+		want    Commit
+		wantErr string
+	}{
+		{
+			title:   "emoji",
+			message: "✨ add a feature",
+			want:    Commit{Type: "feat", Subject: "add a feature", Header: "✨ add a feature"},
+		},
+		{
+			title:   "shortcode",
+			message: ":bug: fix a bug",
+			want:    Commit{Type: "fix", Subject: "fix a bug", Header: ":bug: fix a bug"},
+		},
+		{
+			title:   "variation selector",
+			message: "⚡️ go faster",
+			want:    Commit{Type: "perf", Subject: "go faster", Header: "⚡️ go faster"},
+		},
+		{
+			title:   "scope and colon",
+			message: "🐛 (parser): fix a bug",
+			want:    Commit{Type: "fix", Scope: "parser", Subject: "fix a bug", Header: "🐛 (parser): fix a bug"},
+		},
+		{
+			title:   "breaking gitmoji",
+			message: ":boom: remove the api",
+			want:    Commit{Type: "feat", Subject: "remove the api", Breaking: true, Header: ":boom: remove the api"},
+		},
+		{
+			title:   "body and breaking footer",
+			message: "♻️ rework the api\n\nsome details\n\nBREAKING CHANGE: the api changed",
+			want: Commit{
+				Type:     "refactor",
+				Subject:  "rework the api",
+				Body:     "some details",
+				Breaking: true,
+				Header:   "♻️ rework the api",
+				Footers:  []Footer{{Title: BreakingChange, Text: "the api changed", Separator: ColonSeparator}},
+			},
+		},
+		{
+			title:   "custom types",
+			types:   map[string]string{":art:": "style", "✨": "feature"},
+			message: ":art: format code",
+			want:    Commit{Type: "style", Subject: "format code", Header: ":art: format code"},
+		},
+		{
+			title:   "custom types override defaults",
+			types:   map[string]string{"✨": "feature"},
+			message: "✨ add a feature",
+			want:    Commit{Type: "feature", Subject: "add a feature", Header: "✨ add a feature"},
+		},
+		{
+			title:   "empty message",
+			wantErr: "empty commit message",
+		},
+		{
+			title:   "conventional commit",
+			message: "feat: add a feature",
+			wantErr: `invalid header "feat: add a feature": column 1: missing gitmoji`,
+		},
+		{
+			title:   "unknown gitmoji",
+			message: ":art: format code",
+			wantErr: `invalid header ":art: format code": column 1: unknown gitmoji :art:`,
+		},
+		{
+			title:   "empty scope",
+			message: "🐛 () fix a bug",
+			wantErr: `invalid header "🐛 () fix a bug": column 6: invalid scope`,
+		},
+		{
+			title:   "missing description",
+			message: ":bug:",
+			wantErr: `invalid header ":bug:": column 6: missing description`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			p := &Gitmoji{Types: tt.types}
+			got, err := p.Parse(tt.message)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRegex(t *testing.T) {
+	_, err := NewRegex(`^(?P<subject>.*)`)
+	assert.EqualError(t, err, "pattern must have a named group called type")
+
+	_, err = NewRegex(`^(?P<type>`)
+	assert.Error(t, err)
+}
+
+func TestRegex_Parse(t *testing.T) {
+	tests := []struct {
+		title   string
+		pattern string
+		message string
+		want    Commit
+		wantErr string
+	}{
+		{
+			title:   "angular breaking body",
+			pattern: `(?s)^(?P<type>\w+)(?:\((?P<scope>[^)]+)\))?: (?P<subject>[^\n]+)(?P<breaking>.*\nBREAKING: .*)?`,
+			message: "feat(api): add a feature\n\nBREAKING: the api changed",
+			want: Commit{
+				Type:     "feat",
+				Scope:    "api",
+				Subject:  "add a feature",
+				Breaking: true,
+				Header:   "feat(api): add a feature",
+				Footers:  []Footer{{Title: "BREAKING", Text: "the api changed", Separator: ColonSeparator}},
+			},
+		},
+		{
+			title:   "hashtag",
+			pattern: `(?s)^.*#(?P<type>feat|fix)\b`,
+			message: "Add a feature #feat\n\nMore details.",
+			want:    Commit{Type: "feat", Subject: "Add a feature #feat", Body: "More details.", Header: "Add a feature #feat"},
+		},
+		{
+			title:   "breaking footer",
+			pattern: `^\[(?P<type>\w+)\] (?P<subject>.*)`,
+			message: "[fix] fix a bug\n\nBREAKING CHANGE: the bug was a feature",
+			want: Commit{
+				Type:     "fix",
+				Subject:  "fix a bug",
+				Breaking: true,
+				Header:   "[fix] fix a bug",
+				Footers:  []Footer{{Title: BreakingChange, Text: "the bug was a feature", Separator: ColonSeparator}},
+			},
+		},
+		{
+			title:   "no match",
+			pattern: `^\[(?P<type>\w+)\]`,
+			message: "fix a bug",
+			wantErr: `message does not match ^\[(?P<type>\w+)\]`,
+		},
+		{
+			title:   "empty type",
+			pattern: `^(?P<type>\w*)`,
+			message: "!fix a bug",
+			wantErr: "message does not have a type: !fix a bug",
+		},
+		{
+			title:   "empty message",
+			pattern: `(?P<type>\w+)`,
+			wantErr: "empty commit message",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			p, err := NewRegex(tt.pattern)
+			require.NoError(t, err)
+
+			got, err := p.Parse(tt.message)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
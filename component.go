@@ -0,0 +1,121 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sassoftware/gotagger/internal/git"
+)
+
+// versionsComponents returns the versions of the configured components.
+func (g *Gotagger) versionsComponents() ([]release, error) {
+	g.logger.Info("versioning components")
+
+	if len(g.Config.Paths) > 0 {
+		return nil, errors.New("cannot use path filtering with components")
+	}
+
+	releases := make([]release, len(g.Config.Components))
+	for i, component := range g.Config.Components {
+		logger := g.logger.WithValues("component", component.Name)
+
+		// apply any configuration overrides for this component
+		cg := g.forComponent(component)
+		prefix := cg.componentPrefix(component)
+
+		tags, err := g.tags(g.rev(), prefix)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("found tags", "tags", tags)
+
+		latest, hash, err := cg.latest(tags, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		commits, err := g.revList(g.rev(), hash, component.paths()...)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch commits %s..%s: %w", g.rev(), hash, err)
+		}
+
+		// group the commits by component,
+		// so commits that only touched a more specific path of another
+		// component are not counted
+		commits = g.groupCommitsByComponent(commits)[component.Name]
+
+		version, err := cg.incrementVersion(latest, commits)
+		if err != nil {
+			return nil, fmt.Errorf("could not increment version: %w", err)
+		}
+
+		releases[i] = release{
+			component: component.Name,
+			prefix:    prefix,
+			version:   version,
+			previous:  cg.previousVersion(latest, hash),
+			commits:   commits,
+			changed:   len(commits) > 0 && version != cg.strategy().Format(latest),
+		}
+	}
+
+	return releases, nil
+}
+
+// forComponent returns a Gotagger whose Config includes the overrides for c.
+func (g *Gotagger) forComponent(c Component) *Gotagger {
+	g.logger.Info("applying component configuration", "component", c.Name)
+
+	cg := g.withOverrides(c.Overrides)
+	if c.VersionStrategy != nil {
+		cg.Config.VersionStrategy = c.VersionStrategy
+	}
+
+	return cg
+}
+
+// componentPrefix returns the tag prefix of c.
+//
+// The default prefix is the name of the component followed by a slash
+// and the version prefix.
+func (g *Gotagger) componentPrefix(c Component) string {
+	if c.TagPrefix != "" {
+		return c.TagPrefix
+	}
+
+	return c.Name + goModSep + g.Config.VersionPrefix
+}
+
+// groupCommitsByComponent groups commits by the names of the components they changed.
+//
+// A file belongs to the component with the most specific path that contains it.
+func (g *Gotagger) groupCommitsByComponent(commits []git.Commit) map[string][]git.Commit {
+	g.logger.Info("group commits by component")
+
+	pathMap := map[string]string{}
+	for _, component := range g.Config.Components {
+		for _, p := range component.paths() {
+			pathMap[filepath.FromSlash(p)] = component.Name
+		}
+	}
+
+	return g.groupCommits(commits, pathMap)
+}
+
+// changedComponents returns the releases,
+// without the components whose version is unchanged since their previous release,
+// or that have never been released and have no changes.
+func changedComponents(releases []release) []release {
+	changed := make([]release, 0, len(releases))
+	for _, r := range releases {
+		if r.component == "" || r.changed {
+			changed = append(changed, r)
+		}
+	}
+
+	return changed
+}
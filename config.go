@@ -2,19 +2,115 @@ package gotagger
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/BurntSushi/toml"
+	"github.com/sassoftware/gotagger/commit"
 	"github.com/sassoftware/gotagger/mapper"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFiles are the names of the config files gotagger looks for,
+// in order of precedence.
+var ConfigFiles = []string{
+	"gotagger.json",
+	".gotagger.json",
+	".gotagger.yaml",
+	".gotagger.yml",
+	".gotagger.toml",
+}
+
+// Module validation modes of release commits.
+const (
+	// ValidateCommit requires the modules of a release commit
+	// to be the modules changed by the release commit itself.
+	ValidateCommit = "commit"
+
+	// ValidateUnreleased requires the modules of a release commit
+	// to have unreleased changes, and warns about modules with
+	// unreleased changes that the release commit does not release.
+	ValidateUnreleased = "unreleased"
 )
 
 type config struct {
-	DefaultIncrement         string            `json:"defaultIncrement"`
-	IncrementDirtyWorktree   string            `json:"incrementDirtyWorktree"`
-	ExcludeModules           []string          `json:"excludeModules"`
-	IgnoreModules            bool              `json:"ignoreModules"`
-	IncrementMappings        map[string]string `json:"incrementMappings"`
-	IncrementPreReleaseMinor bool              `json:"incrementPreReleaseMinor"`
-	VersionPrefix            *string           `json:"versionPrefix"`
+	CreateTag                bool                    `json:"createTag" yaml:"createTag" toml:"createTag" description:"Create tags for release commits. Implied by pushTag and force."`
+	DefaultIncrement         string                  `json:"defaultIncrement" yaml:"defaultIncrement" toml:"defaultIncrement" description:"How to increment the version for commit types not listed in incrementMappings." enum:"minor,patch,none"`
+	IncrementDirtyWorktree   string                  `json:"incrementDirtyWorktree" yaml:"incrementDirtyWorktree" toml:"incrementDirtyWorktree" description:"How to increment the version when there are no new commits, but the worktree is dirty." enum:"minor,patch,none"`
+	ExcludeModules           []string                `json:"excludeModules" yaml:"excludeModules" toml:"excludeModules" description:"Names or paths of go modules to exclude from versioning."`
+	Force                    bool                    `json:"force" yaml:"force" toml:"force" description:"Create tags even if the commit is not a release commit. Implies createTag."`
+	IgnoreModules            bool                    `json:"ignoreModules" yaml:"ignoreModules" toml:"ignoreModules" description:"Ignore go.mod files when versioning the project."`
+	IncrementMappings        map[string]string       `json:"incrementMappings" yaml:"incrementMappings" toml:"incrementMappings" description:"Mapping of commit type to version increment."`
+	IncrementPreReleaseMinor bool                    `json:"incrementPreReleaseMinor" yaml:"incrementPreReleaseMinor" toml:"incrementPreReleaseMinor" description:"Increment the minor version instead of the major version for breaking changes to 0.x versions."`
+	Paths                    []string                `json:"paths" yaml:"paths" toml:"paths" description:"Paths within the repository to version separately when not versioning go modules."`
+	PushTag                  bool                    `json:"pushTag" yaml:"pushTag" toml:"pushTag" description:"Push the tags gotagger creates to the remote repository. Implies createTag."`
+	RemoteName               *string                 `json:"remoteName" yaml:"remoteName" toml:"remoteName" description:"Name of the remote repository to push tags to. Defaults to origin."`
+	VersionPrefix            *string                 `json:"versionPrefix" yaml:"versionPrefix" toml:"versionPrefix" description:"Prefix added to versions. Defaults to v."`
+	SignTags                 bool                    `json:"signTags" yaml:"signTags" toml:"signTags" description:"Sign the tags gotagger creates."`
+	SigningKey               string                  `json:"signingKey" yaml:"signingKey" toml:"signingKey" description:"Key used to sign tags. Implies signTags."`
+	SigningFormat            string                  `json:"signingFormat" yaml:"signingFormat" toml:"signingFormat" description:"Signature format used to sign tags. Defaults to the gpg.format git config setting." enum:"openpgp,x509,ssh"`
+	VerifyTags               bool                    `json:"verifyTags" yaml:"verifyTags" toml:"verifyTags" description:"Fail if the tag of the base version does not have a valid signature."`
+	IgnoreUnsignedTags       bool                    `json:"ignoreUnsignedTags" yaml:"ignoreUnsignedTags" toml:"ignoreUnsignedTags" description:"Skip tags without a valid signature when finding the base version."`
+	TagMessage               string                  `json:"tagMessage" yaml:"tagMessage" toml:"tagMessage" description:"Go text/template used to generate the message of the tags gotagger creates."`
+	LightweightTags          bool                    `json:"lightweightTags" yaml:"lightweightTags" toml:"lightweightTags" description:"Create lightweight tags instead of annotated tags."`
+	Modules                  map[string]moduleConfig `json:"modules" yaml:"modules" toml:"modules" description:"Configuration overrides for individual go modules, indexed by module name or path."`
+	Components               []componentConfig       `json:"components" yaml:"components" toml:"components" description:"Named parts of the repository that are versioned independently."`
+	VersionStrategy          *string                 `json:"versionStrategy" yaml:"versionStrategy" toml:"versionStrategy" description:"How versions are calculated. Defaults to semver." enum:"semver,calver"`
+	CalVerFormat             *string                 `json:"calverFormat" yaml:"calverFormat" toml:"calverFormat" description:"Format of calendar versions when versionStrategy is calver. Defaults to YYYY.0M.MICRO."`
+	VersionFiles             []versionFileConfig     `json:"versionFiles" yaml:"versionFiles" toml:"versionFiles" description:"Files that contain the version of the project, updated by the bump-files command."`
+	OutputFormat             string                  `json:"outputFormat" yaml:"outputFormat" toml:"outputFormat" description:"Format of the versions gotagger prints. Tags are always semantic versions. Defaults to semver." enum:"semver,pep440,maven,debian"`
+	GoVersion                goVersionConfig         `json:"goVersion" yaml:"goVersion" toml:"goVersion" description:"Generated go version files and linker flags of the go-version command."`
+	CommitParser             *string                 `json:"commitParser" yaml:"commitParser" toml:"commitParser" description:"Commit message convention used to parse commits. Defaults to conventional." enum:"conventional,gitmoji,regex"`
+	CommitPattern            *string                 `json:"commitPattern" yaml:"commitPattern" toml:"commitPattern" description:"Regular expression with named groups type, scope, subject, and breaking, used to parse commits when commitParser is regex."`
+	IgnoreAuthors            []string                `json:"ignoreAuthors" yaml:"ignoreAuthors" toml:"ignoreAuthors" description:"Email addresses of commit authors, such as bots, whose commits are ignored when calculating versions. May be glob patterns."`
+	IgnoreMerges             bool                    `json:"ignoreMerges" yaml:"ignoreMerges" toml:"ignoreMerges" description:"Ignore merge commits when calculating versions."`
+	FirstParent              bool                    `json:"firstParent" yaml:"firstParent" toml:"firstParent" description:"Only follow the first parent of merge commits, so commits on merged branches are ignored."`
+	ModuleValidation         string                  `json:"moduleValidation" yaml:"moduleValidation" toml:"moduleValidation" description:"How the modules of release commits are validated: against the modules changed by the release commit, or against the modules with unreleased changes. Defaults to commit." enum:"commit,unreleased"`
+	GitmojiTypes             map[string]string       `json:"gitmojiTypes" yaml:"gitmojiTypes" toml:"gitmojiTypes" description:"Mapping of gitmoji to commit type when commitParser is gitmoji, in addition to the default mappings."`
+}
+
+type componentConfig struct {
+	Name                     string            `json:"name" yaml:"name" toml:"name" description:"Name of the component."`
+	Paths                    []string          `json:"paths" yaml:"paths" toml:"paths" description:"Paths within the repository that belong to the component. Defaults to the name of the component."`
+	TagPrefix                string            `json:"tagPrefix" yaml:"tagPrefix" toml:"tagPrefix" description:"Prefix of the tags of the component. Defaults to the name of the component, followed by a slash and the version prefix."`
+	DefaultIncrement         *string           `json:"defaultIncrement" yaml:"defaultIncrement" toml:"defaultIncrement" description:"How to increment the version for commit types not listed in incrementMappings." enum:"minor,patch,none"`
+	IncrementDirtyWorktree   *string           `json:"incrementDirtyWorktree" yaml:"incrementDirtyWorktree" toml:"incrementDirtyWorktree" description:"How to increment the version when there are no new commits, but the worktree is dirty." enum:"minor,patch,none"`
+	IncrementMappings        map[string]string `json:"incrementMappings" yaml:"incrementMappings" toml:"incrementMappings" description:"Mapping of commit type to version increment."`
+	IncrementPreReleaseMinor *bool             `json:"incrementPreReleaseMinor" yaml:"incrementPreReleaseMinor" toml:"incrementPreReleaseMinor" description:"Increment the minor version instead of the major version for breaking changes to 0.x versions."`
+	VersionPrefix            *string           `json:"versionPrefix" yaml:"versionPrefix" toml:"versionPrefix" description:"Version prefix used by the default tag prefix."`
+	VersionStrategy          *string           `json:"versionStrategy" yaml:"versionStrategy" toml:"versionStrategy" description:"How versions of the component are calculated." enum:"semver,calver"`
+	CalVerFormat             *string           `json:"calverFormat" yaml:"calverFormat" toml:"calverFormat" description:"Format of calendar versions when versionStrategy is calver. Defaults to YYYY.0M.MICRO."`
+}
+
+type versionFileConfig struct {
+	Path      string `json:"path" yaml:"path" toml:"path" description:"Path of the file, relative to the top of the repository."`
+	Pattern   string `json:"pattern" yaml:"pattern" toml:"pattern" description:"Regular expression that matches the version. The version is the first capture group, or the whole match."`
+	Key       string `json:"key" yaml:"key" toml:"key" description:"Dotted path of the version in a JSON, YAML, or TOML file, such as tool.poetry.version."`
+	Format    string `json:"format" yaml:"format" toml:"format" description:"Format of the version in the file. Defaults to semver, without the tag prefix." enum:"semver,pep440,maven,debian"`
+	Component string `json:"component" yaml:"component" toml:"component" description:"Name of the component, go module, or path whose version is written. Defaults to the first version."`
+}
+
+type goVersionConfig struct {
+	File       string `json:"file" yaml:"file" toml:"file" description:"Name of the go file written to the directory of each go module. Defaults to version.go."`
+	Package    string `json:"package" yaml:"package" toml:"package" description:"Package name of the generated go files. Defaults to the package of the go files in the module directory."`
+	Var        string `json:"var" yaml:"var" toml:"var" description:"Name of the version variable. Defaults to Version."`
+	CommitVar  string `json:"commitVar" yaml:"commitVar" toml:"commitVar" description:"Name of the variable that the linker flags set to the commit hash. Defaults to Commit."`
+	ImportPath string `json:"importPath" yaml:"importPath" toml:"importPath" description:"Import path of the package whose variables the linker flags set. Defaults to main."`
+}
+
+type moduleConfig struct {
+	DefaultIncrement         *string           `json:"defaultIncrement" yaml:"defaultIncrement" toml:"defaultIncrement" description:"How to increment the version for commit types not listed in incrementMappings." enum:"minor,patch,none"`
+	IncrementDirtyWorktree   *string           `json:"incrementDirtyWorktree" yaml:"incrementDirtyWorktree" toml:"incrementDirtyWorktree" description:"How to increment the version when there are no new commits, but the worktree is dirty." enum:"minor,patch,none"`
+	IncrementMappings        map[string]string `json:"incrementMappings" yaml:"incrementMappings" toml:"incrementMappings" description:"Mapping of commit type to version increment."`
+	IncrementPreReleaseMinor *bool             `json:"incrementPreReleaseMinor" yaml:"incrementPreReleaseMinor" toml:"incrementPreReleaseMinor" description:"Increment the minor version instead of the major version for breaking changes to 0.x versions."`
+	VersionPrefix            *string           `json:"versionPrefix" yaml:"versionPrefix" toml:"versionPrefix" description:"Prefix added to versions."`
 }
 
 // Config represents how to tag a repo.
@@ -34,6 +130,13 @@ type Config struct {
 	// RemoteName represents the name of the remote repository. Defaults to origin.
 	RemoteName string
 
+	// Rev is the git revision to version and tag. Defaults to HEAD.
+	//
+	// When Rev is not HEAD, go modules are found in the tree of Rev,
+	// and the state of the worktree is ignored.
+	// Rev is set for each invocation, so it is not a config file option.
+	Rev string
+
 	// PreMajor controls whether gotagger will increase the major version from 0
 	// to 1 for breaking changes.
 	PreMajor bool
@@ -41,9 +144,47 @@ type Config struct {
 	// PushTag represents whether to push the tag to the remote git repository.
 	PushTag bool
 
+	// SignTags controls whether the tags gotagger creates are signed.
+	SignTags bool
+
+	// SigningKey is the key used to sign tags. Setting this implies SignTags.
+	// If empty, then git uses the default key for the committer.
+	SigningKey string
+
+	// SigningFormat is the signature format used to sign tags: openpgp, x509, or ssh.
+	// If empty, then git uses the gpg.format git config setting.
+	SigningFormat string
+
+	// VerifyTags controls whether gotagger verifies the signature of the tag
+	// it uses as the base version. A tag without a valid signature is an error.
+	VerifyTags bool
+
+	// IgnoreUnsignedTags controls whether gotagger skips tags without a valid
+	// signature when it determines the base version.
+	IgnoreUnsignedTags bool
+
+	// TagMessage is a go text/template used to generate the message of the
+	// tags gotagger creates. The template is executed with a TagMessageData.
+	// Defaults to "Release " followed by the tag name.
+	TagMessage string
+
+	// LightweightTags controls whether gotagger creates lightweight tags
+	// instead of annotated tags. Lightweight tags cannot be signed.
+	LightweightTags bool
+
 	// VersionPrefix is a string that will be added to the front of the version. Defaults to 'v'.
 	VersionPrefix string
 
+	// VersionStrategy calculates versions. Defaults to SemVer if nil.
+	// Go modules must use SemVer.
+	VersionStrategy VersionStrategy
+
+	// OutputFormat is the format of the versions returned by gotagger,
+	// one of the OutputFormats. Versions in formats other than FormatSemVer
+	// do not include the tag prefix. Tags are not affected.
+	// Defaults to FormatSemVer.
+	OutputFormat string
+
 	// DirtyWorktreeIncrement is a string that sets how to increment the version
 	// if there are no new commits, but the worktree is "dirty".
 	DirtyWorktreeIncrement mapper.Increment
@@ -55,10 +196,50 @@ type Config struct {
 	Force bool
 
 	// Paths is a list of sub-paths within the repo to restrict the git
-	// history used to calculate a version. The versions returned will be
-	// prefixed with their path.
+	// history used to calculate a version. All paths share the VersionPrefix,
+	// use Components to version paths with their own tag prefixes.
 	Paths []string
 
+	// Components are named parts of the repository that are versioned
+	// independently, each with its own tag prefix.
+	// Components cannot be used with go modules or Paths.
+	Components []Component
+
+	// Modules overrides the configuration of individual go modules,
+	// indexed by module name or module path.
+	Modules map[string]ModuleConfig
+
+	// VersionFiles are files that contain the version of the project.
+	// See BumpFiles and CheckFiles.
+	VersionFiles []VersionFile
+
+	// GoVersion configures the go version files and linker flags of go modules.
+	// See GenerateGoVersion and LDFlags.
+	GoVersion GoVersion
+
+	// CommitParser parses commit messages.
+	// Defaults to Conventional Commits if nil.
+	CommitParser commit.Parser
+
+	// IgnoreAuthors are the email addresses of commit authors whose commits
+	// are ignored when gotagger calculates versions, such as bots.
+	// Addresses may be glob patterns, and are matched case-insensitively.
+	// Commits with a "Release-Ignore: true" footer are always ignored.
+	IgnoreAuthors []string
+
+	// IgnoreMerges controls whether gotagger ignores merge commits
+	// when it calculates versions.
+	IgnoreMerges bool
+
+	// FirstParent controls whether gotagger only follows the first parent
+	// of merge commits, so that the commits of merged branches are ignored
+	// and only the merge commits themselves are counted.
+	FirstParent bool
+
+	// ModuleValidation is how gotagger validates the modules of release commits:
+	// ValidateCommit or ValidateUnreleased. Defaults to ValidateCommit.
+	ModuleValidation string
+
 	/* TODO
 	// PreRelease is the string that will be used to generate pre-release versions. The
 	// string may be a Golang text template. Valid arguments are:
@@ -69,6 +250,316 @@ type Config struct {
 	*/
 }
 
+// ModuleConfig overrides the Config of a go module.
+//
+// Fields that are nil use the value from the Config.
+type ModuleConfig struct {
+	// CommitTypeTable used for looking up version increments based on the commit type.
+	CommitTypeTable *mapper.Table
+
+	// PreMajor controls whether gotagger will increase the major version from 0
+	// to 1 for breaking changes.
+	PreMajor *bool
+
+	// VersionPrefix is a string that will be added to the front of the version.
+	VersionPrefix *string
+
+	// DirtyWorktreeIncrement sets how to increment the version
+	// if there are no new commits, but the worktree is "dirty".
+	DirtyWorktreeIncrement *mapper.Increment
+}
+
+// Component is a named part of a repository, such as a frontend or a service,
+// that is versioned independently of the rest of the repository.
+type Component struct {
+	// Name is the name of the component.
+	Name string
+
+	// Paths are the paths within the repository that belong to the component.
+	// Files belong to the component with the most specific path that contains them.
+	// Defaults to Name.
+	Paths []string
+
+	// TagPrefix is the prefix of the tags of the component, such as "frontend/v".
+	// Defaults to Name, followed by a slash and the VersionPrefix.
+	TagPrefix string
+
+	// VersionStrategy calculates the versions of the component.
+	// Defaults to the VersionStrategy of the Config if nil.
+	VersionStrategy VersionStrategy
+
+	// Overrides overrides the Config of the component.
+	Overrides ModuleConfig
+}
+
+// paths returns the paths of c.
+func (c Component) paths() []string {
+	if len(c.Paths) == 0 {
+		return []string{c.Name}
+	}
+
+	return c.Paths
+}
+
+// FindConfigFile searches dir and each of its parents, up to and including root,
+// for one of the ConfigFiles, and returns the path of the first one found.
+//
+// If no config file is found, then FindConfigFile returns the empty string.
+func FindConfigFile(dir, root string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range ConfigFiles {
+			fn := filepath.Join(dir, name)
+			info, err := os.Stat(fn)
+			if err == nil && !info.IsDir() {
+				return fn, nil
+			}
+
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return "", err
+			}
+		}
+
+		// stop at the root, or if dir is not beneath root
+		parent := filepath.Dir(dir)
+		if rel, err := filepath.Rel(root, dir); err != nil || rel == "." || strings.HasPrefix(rel, "..") || parent == dir {
+			return "", nil
+		}
+
+		dir = parent
+	}
+}
+
+// ParseFile reads the config file name, and parses it based on its extension.
+//
+// Files ending in .yaml or .yml are parsed as YAML, files ending in .toml are
+// parsed as TOML, and all other files are parsed as JSON.
+func (c *Config) ParseFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		err = c.ParseYAML(data)
+	case ".toml":
+		err = c.ParseTOML(data)
+	default:
+		err = c.ParseJSON(data)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid config file %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// FileOptions returns the names of the options set in the config file name,
+// in sorted order.
+func FileOptions(name string) ([]string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys map[string]interface{}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &keys)
+	case ".toml":
+		err = toml.Unmarshal(data, &keys)
+	default:
+		err = json.Unmarshal(data, &keys)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", name, err)
+	}
+
+	options := make([]string, 0, len(keys))
+	for key := range keys {
+		options = append(options, key)
+	}
+	sort.Strings(options)
+
+	return options, nil
+}
+
+// Options returns the value of each config file option for c,
+// indexed by option name.
+//
+// Parsing a config file that contains these options results in a Config
+// equivalent to c.
+func (c Config) Options() map[string]interface{} {
+	mappings := make(map[string]string, len(c.CommitTypeTable.Mapper))
+	for typ, inc := range c.CommitTypeTable.Mapper {
+		mappings[typ] = inc.String()
+	}
+
+	var modules map[string]interface{}
+	for name, m := range c.Modules {
+		if modules == nil {
+			modules = make(map[string]interface{}, len(c.Modules))
+		}
+		modules[name] = m.options()
+	}
+
+	var components []map[string]interface{}
+	for _, component := range c.Components {
+		options := component.Overrides.options()
+		options["name"] = component.Name
+		if len(component.Paths) > 0 {
+			options["paths"] = component.Paths
+		}
+		if component.TagPrefix != "" {
+			options["tagPrefix"] = component.TagPrefix
+		}
+		if component.VersionStrategy != nil {
+			options["versionStrategy"], options["calverFormat"] = strategyOptions(component.VersionStrategy)
+		}
+		components = append(components, options)
+	}
+
+	strategy, calverFormat := strategyOptions(c.VersionStrategy)
+	parser, pattern, gitmojiTypes := parserOptions(c.CommitParser)
+
+	var versionFiles []map[string]interface{}
+	for _, f := range c.VersionFiles {
+		versionFiles = append(versionFiles, map[string]interface{}{
+			"path":      f.Path,
+			"pattern":   f.Pattern,
+			"key":       f.Key,
+			"format":    f.Format,
+			"component": f.Component,
+		})
+	}
+
+	return map[string]interface{}{
+		"createTag":                c.CreateTag,
+		"defaultIncrement":         c.CommitTypeTable.Default().String(),
+		"incrementDirtyWorktree":   c.DirtyWorktreeIncrement.String(),
+		"excludeModules":           c.ExcludeModules,
+		"force":                    c.Force,
+		"ignoreModules":            c.IgnoreModules,
+		"incrementMappings":        mappings,
+		"incrementPreReleaseMinor": c.PreMajor,
+		"paths":                    c.Paths,
+		"pushTag":                  c.PushTag,
+		"remoteName":               c.RemoteName,
+		"versionPrefix":            c.VersionPrefix,
+		"signTags":                 c.SignTags,
+		"signingKey":               c.SigningKey,
+		"signingFormat":            c.SigningFormat,
+		"verifyTags":               c.VerifyTags,
+		"ignoreUnsignedTags":       c.IgnoreUnsignedTags,
+		"tagMessage":               c.TagMessage,
+		"lightweightTags":          c.LightweightTags,
+		"modules":                  modules,
+		"components":               components,
+		"versionStrategy":          strategy,
+		"calverFormat":             calverFormat,
+		"outputFormat":             c.outputFormat(),
+		"versionFiles":             versionFiles,
+		"commitParser":             parser,
+		"commitPattern":            pattern,
+		"gitmojiTypes":             gitmojiTypes,
+		"ignoreAuthors":            c.IgnoreAuthors,
+		"ignoreMerges":             c.IgnoreMerges,
+		"firstParent":              c.FirstParent,
+		"moduleValidation":         c.moduleValidation(),
+		"goVersion": map[string]interface{}{
+			"file":       c.GoVersion.file(),
+			"package":    c.GoVersion.Package,
+			"var":        c.GoVersion.variable(),
+			"commitVar":  c.GoVersion.commitVariable(),
+			"importPath": c.GoVersion.importPath(),
+		},
+	}
+}
+
+// outputFormat returns the output format, defaulting to FormatSemVer.
+func (c Config) outputFormat() string {
+	if c.OutputFormat == "" {
+		return FormatSemVer
+	}
+
+	return c.OutputFormat
+}
+
+// moduleValidation returns the module validation mode, defaulting to ValidateCommit.
+func (c Config) moduleValidation() string {
+	if c.ModuleValidation == "" {
+		return ValidateCommit
+	}
+
+	return c.ModuleValidation
+}
+
+// strategyOptions returns the name and calver format of strategy.
+func strategyOptions(strategy VersionStrategy) (name string, calverFormat interface{}) {
+	if strategy == nil {
+		strategy = SemVer{}
+	}
+
+	if calver, ok := strategy.(*CalVer); ok {
+		return strategy.Name(), calver.Layout
+	}
+
+	return strategy.Name(), nil
+}
+
+// parserOptions returns the name, regular expression, and gitmoji types
+// of the commit parser p.
+func parserOptions(p commit.Parser) (name string, pattern interface{}, gitmojiTypes map[string]string) {
+	switch p := p.(type) {
+	case nil:
+		return commit.ConventionalParser, nil, nil
+	case *commit.Regex:
+		return p.Name(), p.Pattern(), nil
+	case *commit.Gitmoji:
+		return p.Name(), nil, p.Types
+	default:
+		return p.Name(), nil, nil
+	}
+}
+
+// options returns the value of each config file option that m overrides,
+// indexed by option name.
+func (m ModuleConfig) options() map[string]interface{} {
+	options := make(map[string]interface{})
+	if m.CommitTypeTable != nil {
+		mappings := make(map[string]string, len(m.CommitTypeTable.Mapper))
+		for typ, inc := range m.CommitTypeTable.Mapper {
+			mappings[typ] = inc.String()
+		}
+
+		options["defaultIncrement"] = m.CommitTypeTable.Default().String()
+		options["incrementMappings"] = mappings
+	}
+	if m.DirtyWorktreeIncrement != nil {
+		options["incrementDirtyWorktree"] = m.DirtyWorktreeIncrement.String()
+	}
+	if m.PreMajor != nil {
+		options["incrementPreReleaseMinor"] = *m.PreMajor
+	}
+	if m.VersionPrefix != nil {
+		options["versionPrefix"] = *m.VersionPrefix
+	}
+
+	return options
+}
+
 // ParseJSON unmarshals a byte slice containing mappings of commit type to semver increment. Mappings determine
 // how much to increment the semver based on the commit type. The 'release' commit type has special meaning to gotagger
 // and cannot be overridden in the config file. Unknown commit types will fall back to the config default.
@@ -82,16 +573,226 @@ func (c *Config) ParseJSON(data []byte) error {
 		return err
 	}
 
+	var keys map[string]interface{}
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	if err := checkKeys(keys); err != nil {
+		return err
+	}
+
+	return c.parse(cfg)
+}
+
+// ParseYAML is like ParseJSON, but data is a YAML document.
+func (c *Config) ParseYAML(data []byte) error {
+	cfg := config{
+		IncrementMappings: make(map[string]string),
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	var keys map[string]interface{}
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	if err := checkKeys(keys); err != nil {
+		return err
+	}
+
+	return c.parse(cfg)
+}
+
+// ParseTOML is like ParseJSON, but data is a TOML document.
+func (c *Config) ParseTOML(data []byte) error {
+	cfg := config{
+		IncrementMappings: make(map[string]string),
+	}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	var keys map[string]interface{}
+	if err := toml.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	if err := checkKeys(keys); err != nil {
+		return err
+	}
+
+	return c.parse(cfg)
+}
+
+// checkKeys returns an error if any of the keys of m is not a config option.
+func checkKeys(m map[string]interface{}) error {
+	msgs := unknownOptions(m, reflect.TypeOf(config{}), "")
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// unknownOptions returns a message for each key of m that is not an option
+// of the config struct t. Options that are structs, or maps or slices of
+// structs, are checked recursively.
+func unknownOptions(m map[string]interface{}, t reflect.Type, prefix string) []string {
+	options := structOptions(t)
+
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var msgs []string
+	for _, key := range keys {
+		f, ok := options[key]
+		if !ok {
+			msg := fmt.Sprintf("unknown config option %q", prefix+key)
+			if suggestion := suggest(key, names); suggestion != "" {
+				msg += fmt.Sprintf(", did you mean %q?", suggestion)
+			}
+			msgs = append(msgs, msg)
+			continue
+		}
+
+		// check the options of a struct
+		if f.Type.Kind() == reflect.Struct {
+			if v, ok := m[key].(map[string]interface{}); ok {
+				msgs = append(msgs, unknownOptions(v, f.Type, prefix+key+".")...)
+			}
+			continue
+		}
+
+		// check the options of each element of a map or slice of structs
+		if k := f.Type.Kind(); (k != reflect.Map && k != reflect.Slice) || f.Type.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		switch values := m[key].(type) {
+		case map[string]interface{}:
+			elements := make([]string, 0, len(values))
+			for element := range values {
+				elements = append(elements, element)
+			}
+			sort.Strings(elements)
+
+			for _, element := range elements {
+				if v, ok := values[element].(map[string]interface{}); ok {
+					msgs = append(msgs, unknownOptions(v, f.Type.Elem(), prefix+key+"."+element+".")...)
+				}
+			}
+		case []interface{}:
+			for i, value := range values {
+				if v, ok := value.(map[string]interface{}); ok {
+					msgs = append(msgs, unknownOptions(v, f.Type.Elem(), fmt.Sprintf("%s%s.%d.", prefix, key, i))...)
+				}
+			}
+		case []map[string]interface{}:
+			for i, v := range values {
+				msgs = append(msgs, unknownOptions(v, f.Type.Elem(), fmt.Sprintf("%s%s.%d.", prefix, key, i))...)
+			}
+		}
+	}
+
+	return msgs
+}
+
+// configOptions returns the struct field of each config option,
+// indexed by option name.
+func configOptions() map[string]reflect.StructField {
+	return structOptions(reflect.TypeOf(config{}))
+}
+
+// structOptions returns the fields of the config struct t,
+// indexed by option name.
+func structOptions(t reflect.Type) map[string]reflect.StructField {
+	options := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		options[f.Tag.Get("json")] = f
+	}
+
+	return options
+}
+
+// suggest returns the name that is closest to key,
+// or that starts with key,
+// or the empty string if no name is close enough.
+func suggest(key string, names []string) string {
+	key = strings.ToLower(key)
+
+	var suggestion string
+	best := len(key)/3 + 1
+	for _, name := range names {
+		if d := editDistance(key, strings.ToLower(name)); d < best || (d == best && suggestion == "") {
+			suggestion = name
+			best = d
+		}
+	}
+
+	// fall back to an option that starts with key
+	if suggestion == "" {
+		for _, name := range names {
+			if strings.HasPrefix(strings.ToLower(name), key) {
+				return name
+			}
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = prev[j-1] + cost
+			if d := prev[j] + 1; d < curr[j] {
+				curr[j] = d
+			}
+			if d := curr[j-1] + 1; d < curr[j] {
+				curr[j] = d
+			}
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// parse validates cfg and copies its values into c.
+func (c *Config) parse(cfg config) error {
 	// validate dirty worktree increment
-	inc, err := mapper.Convert(cfg.IncrementDirtyWorktree)
-	switch {
-	case err != nil:
-		return fmt.Errorf("invalid dirty worktree increment: %s", cfg.IncrementDirtyWorktree)
-	case inc == mapper.IncrementMajor:
-		return fmt.Errorf("major version increments are not allowed for dirty worktrees")
-	default:
-		c.DirtyWorktreeIncrement = inc
+	inc, err := parseDirtyIncrement(cfg.IncrementDirtyWorktree)
+	if err != nil {
+		return err
 	}
+	c.DirtyWorktreeIncrement = inc
 
 	// version prefix is a pointer
 	// so the config file can set it to ""
@@ -100,30 +801,55 @@ func (c *Config) ParseJSON(data []byte) error {
 		c.VersionPrefix = *cfg.VersionPrefix
 	}
 
-	// we do not allow configuring the release type,
-	// as it means something particular to gotagger
-	if _, ok := cfg.IncrementMappings["release"]; ok {
-		return fmt.Errorf("release mapping is not allowed")
+	// semver is the default strategy
+	if cfg.VersionStrategy != nil || cfg.CalVerFormat != nil {
+		strategy, err := parseStrategy(cfg.VersionStrategy, cfg.CalVerFormat)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := strategy.(SemVer); ok {
+			strategy = nil
+		}
+		c.VersionStrategy = strategy
 	}
 
-	// generate the commit type table from the parsed mappings
-	var table mapper.Mapper
-	for typ, inc := range cfg.IncrementMappings {
-		conversion, err := mapper.Convert(inc)
+	// validate the author patterns
+	for _, pattern := range cfg.IgnoreAuthors {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid ignoreAuthors pattern: %s", pattern)
+		}
+	}
+
+	// conventional commits is the default parser
+	if cfg.CommitParser != nil || cfg.CommitPattern != nil || len(cfg.GitmojiTypes) > 0 {
+		parser, err := parseParser(cfg.CommitParser, cfg.CommitPattern, cfg.GitmojiTypes)
 		if err != nil {
 			return err
 		}
 
-		if conversion == mapper.IncrementMajor {
-			return fmt.Errorf("major version increments cannot be mapped to commit types. use the commit spec directives for this")
+		if _, ok := parser.(commit.Conventional); ok {
+			parser = nil
 		}
+		c.CommitParser = parser
+	}
 
-		if table == nil {
-			table = make(mapper.Mapper)
+	// validate output format, semver is the default
+	if cfg.OutputFormat != "" {
+		if _, err := RenderVersion("0.0.0", cfg.OutputFormat); err != nil {
+			return err
 		}
 
-		table[typ] = conversion
-		continue
+		c.OutputFormat = cfg.OutputFormat
+		if c.OutputFormat == FormatSemVer {
+			c.OutputFormat = ""
+		}
+	}
+
+	// generate the commit type table from the parsed mappings
+	table, err := parseMappings(cfg.IncrementMappings)
+	if err != nil {
+		return err
 	}
 
 	// default increment to patch
@@ -137,14 +863,353 @@ func (c *Config) ParseJSON(data []byte) error {
 
 	c.CommitTypeTable = mapper.NewTable(table, def)
 
+	// parse module overrides
+	c.Modules = nil
+	for name, mcfg := range cfg.Modules {
+		m, err := c.parseModule(mcfg)
+		if err != nil {
+			return fmt.Errorf("module %s: %w", name, err)
+		}
+
+		if c.Modules == nil {
+			c.Modules = make(map[string]ModuleConfig, len(cfg.Modules))
+		}
+		c.Modules[name] = m
+	}
+
+	// parse components
+	c.Components = nil
+	names := make(map[string]struct{}, len(cfg.Components))
+	for i, ccfg := range cfg.Components {
+		if ccfg.Name == "" {
+			return fmt.Errorf("component %d: missing name", i)
+		}
+		if _, ok := names[ccfg.Name]; ok {
+			return fmt.Errorf("component %s: duplicate name", ccfg.Name)
+		}
+		names[ccfg.Name] = struct{}{}
+
+		component, err := c.parseComponent(ccfg)
+		if err != nil {
+			return fmt.Errorf("component %s: %w", ccfg.Name, err)
+		}
+		c.Components = append(c.Components, component)
+	}
+
+	// parse version files
+	c.VersionFiles = nil
+	for i, fcfg := range cfg.VersionFiles {
+		f, err := parseVersionFile(fcfg)
+		if err != nil {
+			return fmt.Errorf("version file %d: %w", i, err)
+		}
+		c.VersionFiles = append(c.VersionFiles, f)
+	}
+
+	// validate the go version options
+	goVersion := GoVersion(cfg.GoVersion).normalize()
+	if err := goVersion.validate(); err != nil {
+		return fmt.Errorf("goVersion: %w", err)
+	}
+	c.GoVersion = goVersion
+
+	// validate signing format
+	switch cfg.SigningFormat {
+	case "", "openpgp", "x509", "ssh":
+		c.SigningFormat = cfg.SigningFormat
+	default:
+		return fmt.Errorf("invalid signing format: %s", cfg.SigningFormat)
+	}
+
+	// validate module validation mode, commit is the default
+	switch cfg.ModuleValidation {
+	case "", ValidateCommit:
+		c.ModuleValidation = ""
+	case ValidateUnreleased:
+		c.ModuleValidation = cfg.ModuleValidation
+	default:
+		return fmt.Errorf("invalid module validation: %s", cfg.ModuleValidation)
+	}
+
+	// validate the tag message template
+	if _, err := parseTagMessage(cfg.TagMessage); err != nil {
+		return fmt.Errorf("invalid tag message: %w", err)
+	}
+
+	if cfg.LightweightTags && (cfg.SignTags || cfg.SigningKey != "") {
+		return fmt.Errorf("lightweight tags cannot be signed")
+	}
+
+	if cfg.RemoteName != nil {
+		c.RemoteName = *cfg.RemoteName
+	}
+
 	// copy over static values
+	c.CreateTag = cfg.CreateTag || cfg.PushTag || cfg.Force
+	c.Force = cfg.Force
+	c.PushTag = cfg.PushTag
+	c.Paths = cfg.Paths
 	c.ExcludeModules = cfg.ExcludeModules
 	c.IgnoreModules = cfg.IgnoreModules
 	c.PreMajor = cfg.IncrementPreReleaseMinor
+	c.SignTags = cfg.SignTags
+	c.SigningKey = cfg.SigningKey
+	c.VerifyTags = cfg.VerifyTags
+	c.IgnoreUnsignedTags = cfg.IgnoreUnsignedTags
+	c.TagMessage = cfg.TagMessage
+	c.LightweightTags = cfg.LightweightTags
+	c.IgnoreAuthors = cfg.IgnoreAuthors
+	c.IgnoreMerges = cfg.IgnoreMerges
+	c.FirstParent = cfg.FirstParent
 
 	return nil
 }
 
+// parseModule validates the module overrides in cfg.
+//
+// Commit type mappings and the default increment that are not overridden
+// are inherited from c.
+func (c *Config) parseModule(cfg moduleConfig) (ModuleConfig, error) {
+	var m ModuleConfig
+
+	if cfg.IncrementDirtyWorktree != nil {
+		inc, err := parseDirtyIncrement(*cfg.IncrementDirtyWorktree)
+		if err != nil {
+			return m, err
+		}
+		m.DirtyWorktreeIncrement = &inc
+	}
+
+	if cfg.IncrementMappings != nil || cfg.DefaultIncrement != nil {
+		table, err := parseMappings(cfg.IncrementMappings)
+		if err != nil {
+			return m, err
+		}
+		if table == nil {
+			table = c.CommitTypeTable.Mapper
+		}
+
+		def := c.CommitTypeTable.Default()
+		if cfg.DefaultIncrement != nil {
+			if def, err = mapper.Convert(*cfg.DefaultIncrement); err != nil {
+				return m, err
+			}
+		}
+
+		t := mapper.NewTable(table, def)
+		m.CommitTypeTable = &t
+	}
+
+	m.PreMajor = cfg.IncrementPreReleaseMinor
+	m.VersionPrefix = cfg.VersionPrefix
+
+	return m, nil
+}
+
+// parseComponent validates the component configuration cfg.
+func (c *Config) parseComponent(cfg componentConfig) (Component, error) {
+	component := Component{
+		Name:      cfg.Name,
+		TagPrefix: cfg.TagPrefix,
+	}
+
+	for _, p := range cfg.Paths {
+		clean, err := cleanRepoPath(p)
+		if err != nil {
+			return component, err
+		}
+		component.Paths = append(component.Paths, clean)
+	}
+
+	overrides, err := c.parseModule(moduleConfig{
+		DefaultIncrement:         cfg.DefaultIncrement,
+		IncrementDirtyWorktree:   cfg.IncrementDirtyWorktree,
+		IncrementMappings:        cfg.IncrementMappings,
+		IncrementPreReleaseMinor: cfg.IncrementPreReleaseMinor,
+		VersionPrefix:            cfg.VersionPrefix,
+	})
+	if err != nil {
+		return component, err
+	}
+	component.Overrides = overrides
+
+	if cfg.VersionStrategy != nil || cfg.CalVerFormat != nil {
+		component.VersionStrategy, err = parseStrategy(cfg.VersionStrategy, cfg.CalVerFormat)
+		if err != nil {
+			return component, err
+		}
+	}
+
+	return component, nil
+}
+
+// parseVersionFile validates the version file configuration cfg.
+func parseVersionFile(cfg versionFileConfig) (VersionFile, error) {
+	if cfg.Path == "" {
+		return VersionFile{}, errors.New("missing path")
+	}
+
+	path, err := cleanRepoPath(cfg.Path)
+	if err != nil {
+		return VersionFile{}, err
+	}
+
+	f := VersionFile{
+		Path:      path,
+		Pattern:   cfg.Pattern,
+		Key:       cfg.Key,
+		Format:    cfg.Format,
+		Component: cfg.Component,
+	}
+
+	switch {
+	case (f.Pattern == "") == (f.Key == ""):
+		return f, fmt.Errorf("%s: exactly one of pattern or key is required", path)
+	case f.Pattern != "":
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return f, fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+	default:
+		switch ext := strings.ToLower(filepath.Ext(path)); ext {
+		case ".json", ".yaml", ".yml", ".toml":
+		default:
+			return f, fmt.Errorf("%s: keys are not supported in %s files", path, ext)
+		}
+	}
+
+	if f.Format != "" {
+		if _, err := RenderVersion("0.0.0", f.Format); err != nil {
+			return f, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return f, nil
+}
+
+// cleanRepoPath returns the clean, slash-separated form of the path p,
+// or an error if p is not within the repository.
+func cleanRepoPath(p string) (string, error) {
+	clean := filepath.ToSlash(filepath.Clean(filepath.FromSlash(p)))
+	if filepath.IsAbs(p) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("path %s is not within the repository", p)
+	}
+
+	return clean, nil
+}
+
+// parseStrategy returns the version strategy called name.
+//
+// The calver format is only allowed for the calver strategy.
+func parseStrategy(name, calverFormat *string) (VersionStrategy, error) {
+	if name == nil || *name != CalVerStrategy {
+		if calverFormat != nil {
+			return nil, errors.New("calverFormat requires the calver versionStrategy")
+		}
+	}
+
+	switch {
+	case name == nil:
+		return nil, nil
+	case *name == SemVerStrategy:
+		return SemVer{}, nil
+	case *name == CalVerStrategy:
+		layout := DefaultCalVerLayout
+		if calverFormat != nil {
+			layout = *calverFormat
+		}
+
+		return NewCalVer(layout)
+	default:
+		return nil, fmt.Errorf("invalid version strategy: %s", *name)
+	}
+}
+
+// parseParser returns the commit parser called name.
+//
+// The pattern is required by the regex parser, and only allowed for it.
+// The gitmoji types are only allowed for the gitmoji parser.
+func parseParser(name, pattern *string, gitmojiTypes map[string]string) (commit.Parser, error) {
+	isRegex := name != nil && *name == commit.RegexParser
+	if pattern != nil && !isRegex {
+		return nil, errors.New("commitPattern requires the regex commitParser")
+	}
+
+	if len(gitmojiTypes) > 0 && (name == nil || *name != commit.GitmojiParser) {
+		return nil, errors.New("gitmojiTypes requires the gitmoji commitParser")
+	}
+
+	switch {
+	case name == nil:
+		return nil, nil
+	case *name == commit.ConventionalParser:
+		return commit.Conventional{}, nil
+	case *name == commit.GitmojiParser:
+		for emoji, typ := range gitmojiTypes {
+			if emoji == "" || typ == "" {
+				return nil, fmt.Errorf("invalid gitmoji type mapping: %q: %q", emoji, typ)
+			}
+		}
+
+		return &commit.Gitmoji{Types: gitmojiTypes}, nil
+	case isRegex:
+		if pattern == nil {
+			return nil, errors.New("the regex commitParser requires a commitPattern")
+		}
+
+		p, err := commit.NewRegex(*pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commitPattern: %w", err)
+		}
+
+		return p, nil
+	default:
+		return nil, fmt.Errorf("invalid commit parser: %s", *name)
+	}
+}
+
+// parseDirtyIncrement validates the dirty worktree increment inc.
+func parseDirtyIncrement(inc string) (mapper.Increment, error) {
+	i, err := mapper.Convert(inc)
+	switch {
+	case err != nil:
+		return mapper.IncrementNone, fmt.Errorf("invalid dirty worktree increment: %s", inc)
+	case i == mapper.IncrementMajor:
+		return mapper.IncrementNone, fmt.Errorf("major version increments are not allowed for dirty worktrees")
+	default:
+		return i, nil
+	}
+}
+
+// parseMappings validates the commit type mappings and converts them into a
+// mapper.Mapper. If there are no mappings, then parseMappings returns nil.
+func parseMappings(mappings map[string]string) (mapper.Mapper, error) {
+	// we do not allow configuring the release type,
+	// as it means something particular to gotagger
+	if _, ok := mappings["release"]; ok {
+		return nil, fmt.Errorf("release mapping is not allowed")
+	}
+
+	var table mapper.Mapper
+	for typ, inc := range mappings {
+		conversion, err := mapper.Convert(inc)
+		if err != nil {
+			return nil, err
+		}
+
+		if conversion == mapper.IncrementMajor {
+			return nil, fmt.Errorf("major version increments cannot be mapped to commit types. use the commit spec directives for this")
+		}
+
+		if table == nil {
+			table = make(mapper.Mapper)
+		}
+
+		table[typ] = conversion
+	}
+
+	return table, nil
+}
+
 // NewDefaultConfig returns a Config with default options set.
 //
 // If an option is not mentioned, then the default is the zero-value for its type.
@@ -1,10 +1,16 @@
 package gotagger
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/sassoftware/gotagger/commit"
 	"github.com/sassoftware/gotagger/mapper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig_ParseJSON(t *testing.T) {
@@ -168,6 +174,436 @@ func TestConfig_ParseJSON(t *testing.T) {
 			configFileData: `{"incrementDirtyWorktree": "major"}`,
 			wantErr:        "major version increments are not allowed for dirty worktrees",
 		},
+		{
+			title:          "signing config",
+			configFileData: `{"signTags": true, "signingKey": "keyid", "signingFormat": "ssh", "verifyTags": true, "ignoreUnsignedTags": true}`,
+			want: Config{
+				RemoteName:         "origin",
+				VersionPrefix:      "v",
+				CommitTypeTable:    mapper.NewTable(mapper.Mapper{"feat": mapper.IncrementMinor}, mapper.IncrementPatch),
+				SignTags:           true,
+				SigningKey:         "keyid",
+				SigningFormat:      "ssh",
+				VerifyTags:         true,
+				IgnoreUnsignedTags: true,
+			},
+		},
+		{
+			title:          "invalid signing format",
+			configFileData: `{"signingFormat": "pgp"}`,
+			wantErr:        "invalid signing format: pgp",
+		},
+		{
+			title:          "tag message",
+			configFileData: `{"tagMessage": "{{.Tag}}\n{{range .Commits}}- {{.Header}}\n{{end}}", "lightweightTags": false}`,
+			want: Config{
+				RemoteName:      "origin",
+				VersionPrefix:   "v",
+				CommitTypeTable: mapper.NewTable(mapper.Mapper{"feat": mapper.IncrementMinor}, mapper.IncrementPatch),
+				TagMessage:      "{{.Tag}}\n{{range .Commits}}- {{.Header}}\n{{end}}",
+			},
+		},
+		{
+			title:          "invalid tag message",
+			configFileData: `{"tagMessage": "{{.Tag"}`,
+			wantErr:        "invalid tag message: template: tagMessage:1: unclosed action",
+		},
+		{
+			title:          "signed lightweight tags",
+			configFileData: `{"lightweightTags": true, "signTags": true}`,
+			wantErr:        "lightweight tags cannot be signed",
+		},
+		{
+			title:          "unknown option",
+			configFileData: `{"excludeModule": ["foo"]}`,
+			wantErr:        `unknown config option "excludeModule", did you mean "excludeModules"?`,
+		},
+		{
+			title:          "unknown options",
+			configFileData: `{"foo": true, "pushTags": true}`,
+			wantErr:        "unknown config option \"foo\"\nunknown config option \"pushTags\", did you mean \"pushTag\"?",
+		},
+		{
+			title:          "rev option",
+			configFileData: `{"rev": "main"}`,
+			wantErr:        "unknown config option \"rev\"",
+		},
+		{
+			title: "tagging options",
+			configFileData: `{
+	"force": true,
+	"paths": ["foo", "bar"],
+	"pushTag": true,
+	"remoteName": "upstream"
+}`,
+			want: Config{
+				CreateTag:              true,
+				RemoteName:             "upstream",
+				PushTag:                true,
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+				Force:                  true,
+				Paths:                  []string{"foo", "bar"},
+			},
+		},
+		{
+			title:          "create tag",
+			configFileData: `{"createTag": true}`,
+			want: Config{
+				CreateTag:              true,
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title: "module overrides",
+			configFileData: `{
+	"defaultIncrement": "none",
+	"modules": {
+		"foo/bar": {
+			"incrementMappings": {"fix": "minor"},
+			"incrementPreReleaseMinor": true
+		},
+		"baz": {
+			"defaultIncrement": "patch",
+			"incrementDirtyWorktree": "minor",
+			"versionPrefix": ""
+		}
+	}
+}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementNone),
+				Modules: map[string]ModuleConfig{
+					"foo/bar": {
+						CommitTypeTable: tablePtr(mapper.NewTable(mapper.Mapper{"fix": mapper.IncrementMinor}, mapper.IncrementNone)),
+						PreMajor:        boolPtr(true),
+					},
+					"baz": {
+						CommitTypeTable:        tablePtr(mapper.NewTable(nil, mapper.IncrementPatch)),
+						DirtyWorktreeIncrement: incrementPtr(mapper.IncrementMinor),
+						VersionPrefix:          stringPtr(""),
+					},
+				},
+			},
+		},
+		{
+			title:          "invalid module override",
+			configFileData: `{"modules": {"foo": {"incrementMappings": {"release": "minor"}}}}`,
+			wantErr:        "module foo: release mapping is not allowed",
+		},
+		{
+			title:          "invalid module dirty increment",
+			configFileData: `{"modules": {"foo": {"incrementDirtyWorktree": "major"}}}`,
+			wantErr:        "module foo: major version increments are not allowed for dirty worktrees",
+		},
+		{
+			title:          "unknown module option",
+			configFileData: `{"modules": {"foo": {"versionPrefx": ""}}}`,
+			wantErr:        `unknown config option "modules.foo.versionPrefx", did you mean "versionPrefix"?`,
+		},
+		{
+			title: "components",
+			configFileData: `{
+	"components": [
+		{"name": "frontend", "paths": ["web/", "./assets"], "tagPrefix": "frontend/v", "incrementMappings": {"fix": "minor"}},
+		{"name": "service", "versionPrefix": ""}
+	]
+}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+				Components: []Component{
+					{
+						Name:      "frontend",
+						Paths:     []string{"web", "assets"},
+						TagPrefix: "frontend/v",
+						Overrides: ModuleConfig{
+							CommitTypeTable: tablePtr(mapper.NewTable(mapper.Mapper{"fix": mapper.IncrementMinor}, mapper.IncrementPatch)),
+						},
+					},
+					{
+						Name:      "service",
+						Overrides: ModuleConfig{VersionPrefix: stringPtr("")},
+					},
+				},
+			},
+		},
+		{
+			title:          "component missing name",
+			configFileData: `{"components": [{"paths": ["web"]}]}`,
+			wantErr:        "component 0: missing name",
+		},
+		{
+			title:          "duplicate component",
+			configFileData: `{"components": [{"name": "web"}, {"name": "web"}]}`,
+			wantErr:        "component web: duplicate name",
+		},
+		{
+			title:          "component path outside repository",
+			configFileData: `{"components": [{"name": "web", "paths": ["../web"]}]}`,
+			wantErr:        "component web: path ../web is not within the repository",
+		},
+		{
+			title:          "invalid component override",
+			configFileData: `{"components": [{"name": "web", "incrementDirtyWorktree": "major"}]}`,
+			wantErr:        "component web: major version increments are not allowed for dirty worktrees",
+		},
+		{
+			title:          "unknown component option",
+			configFileData: `{"components": [{"name": "web"}, {"name": "api", "tagPrefx": "api-"}]}`,
+			wantErr:        `unknown config option "components.1.tagPrefx", did you mean "tagPrefix"?`,
+		},
+		{
+			title:          "calver strategy",
+			configFileData: `{"versionStrategy": "calver", "components": [{"name": "lib", "versionStrategy": "semver"}]}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				VersionStrategy:        &CalVer{Layout: DefaultCalVerLayout},
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+				Components:             []Component{{Name: "lib", VersionStrategy: SemVer{}}},
+			},
+		},
+		{
+			title:          "calver format",
+			configFileData: `{"versionStrategy": "calver", "calverFormat": "YY.0W.MICRO"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				VersionStrategy:        &CalVer{Layout: "YY.0W.MICRO"},
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "semver strategy",
+			configFileData: `{"versionStrategy": "semver"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "invalid strategy",
+			configFileData: `{"versionStrategy": "romver"}`,
+			wantErr:        "invalid version strategy: romver",
+		},
+		{
+			title:          "invalid calver format",
+			configFileData: `{"versionStrategy": "calver", "calverFormat": "YYYY-0M"}`,
+			wantErr:        `invalid calver format "YYYY-0M": must have two or three parts`,
+		},
+		{
+			title:          "calver format without calver",
+			configFileData: `{"components": [{"name": "web", "calverFormat": "YYYY.0M.MICRO"}]}`,
+			wantErr:        "component web: calverFormat requires the calver versionStrategy",
+		},
+		{
+			title:          "gitmoji parser",
+			configFileData: `{"commitParser": "gitmoji", "gitmojiTypes": {":art:": "style"}}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				CommitParser:           &commit.Gitmoji{Types: map[string]string{":art:": "style"}},
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "regex parser",
+			configFileData: `{"commitParser": "regex", "commitPattern": "^\\[(?P<type>\\w+)\\] (?P<subject>.*)"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				CommitParser:           mustRegex(t, `^\[(?P<type>\w+)\] (?P<subject>.*)`),
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "conventional parser",
+			configFileData: `{"commitParser": "conventional"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "merge commits",
+			configFileData: `{"ignoreMerges": true, "firstParent": true}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				IgnoreMerges:           true,
+				FirstParent:            true,
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "unreleased module validation",
+			configFileData: `{"moduleValidation": "unreleased"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				ModuleValidation:       ValidateUnreleased,
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "commit module validation",
+			configFileData: `{"moduleValidation": "commit"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "invalid module validation",
+			configFileData: `{"moduleValidation": "changes"}`,
+			wantErr:        "invalid module validation: changes",
+		},
+		{
+			title:          "ignore authors",
+			configFileData: `{"ignoreAuthors": ["*[[]bot]@users.noreply.github.com", "ci@example.com"]}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				IgnoreAuthors:          []string{"*[[]bot]@users.noreply.github.com", "ci@example.com"},
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "invalid ignore authors pattern",
+			configFileData: `{"ignoreAuthors": ["[bot"]}`,
+			wantErr:        "invalid ignoreAuthors pattern: [bot",
+		},
+		{
+			title:          "invalid parser",
+			configFileData: `{"commitParser": "angular"}`,
+			wantErr:        "invalid commit parser: angular",
+		},
+		{
+			title:          "regex parser without pattern",
+			configFileData: `{"commitParser": "regex"}`,
+			wantErr:        "the regex commitParser requires a commitPattern",
+		},
+		{
+			title:          "pattern without type",
+			configFileData: `{"commitParser": "regex", "commitPattern": "^(?P<subject>.*)"}`,
+			wantErr:        "invalid commitPattern: pattern must have a named group called type",
+		},
+		{
+			title:          "pattern without regex parser",
+			configFileData: `{"commitPattern": "^(?P<type>\\w+)"}`,
+			wantErr:        "commitPattern requires the regex commitParser",
+		},
+		{
+			title:          "gitmoji types without gitmoji parser",
+			configFileData: `{"gitmojiTypes": {":art:": "style"}}`,
+			wantErr:        "gitmojiTypes requires the gitmoji commitParser",
+		},
+		{
+			title:          "output format",
+			configFileData: `{"outputFormat": "pep440"}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				OutputFormat:           FormatPEP440,
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title:          "invalid output format",
+			configFileData: `{"outputFormat": "rpm"}`,
+			wantErr:        "invalid output format: rpm",
+		},
+		{
+			title: "version files",
+			configFileData: `{"versionFiles": [
+	{"path": "./web/package.json", "key": "version", "component": "web"},
+	{"path": "version.go", "pattern": "Version = \"v(.*)\"", "format": "pep440"}
+]}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+				VersionFiles: []VersionFile{
+					{Path: "web/package.json", Key: "version", Component: "web"},
+					{Path: "version.go", Pattern: `Version = "v(.*)"`, Format: FormatPEP440},
+				},
+			},
+		},
+		{
+			title:          "version file without path",
+			configFileData: `{"versionFiles": [{"key": "version"}]}`,
+			wantErr:        "version file 0: missing path",
+		},
+		{
+			title:          "version file outside repository",
+			configFileData: `{"versionFiles": [{"path": "../package.json", "key": "version"}]}`,
+			wantErr:        "version file 0: path ../package.json is not within the repository",
+		},
+		{
+			title:          "version file with pattern and key",
+			configFileData: `{"versionFiles": [{"path": "package.json", "key": "version", "pattern": "version"}]}`,
+			wantErr:        "version file 0: package.json: exactly one of pattern or key is required",
+		},
+		{
+			title:          "version file invalid pattern",
+			configFileData: `{"versionFiles": [{"path": "VERSION", "pattern": "("}]}`,
+			wantErr:        "version file 0: VERSION: invalid pattern: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			title:          "version file key in unsupported file",
+			configFileData: `{"versionFiles": [{"path": "setup.py", "key": "version"}]}`,
+			wantErr:        "version file 0: setup.py: keys are not supported in .py files",
+		},
+		{
+			title:          "version file invalid format",
+			configFileData: `{"versionFiles": [{"path": "VERSION", "pattern": ".*", "format": "rpm"}]}`,
+			wantErr:        "version file 0: VERSION: invalid output format: rpm",
+		},
+		{
+			title:          "go version",
+			configFileData: `{"goVersion": {"package": "version", "var": "Version", "importPath": "example.com/foo/version"}}`,
+			want: Config{
+				RemoteName:             "origin",
+				VersionPrefix:          "v",
+				DirtyWorktreeIncrement: mapper.IncrementNone,
+				CommitTypeTable:        mapper.NewTable(nil, mapper.IncrementPatch),
+				GoVersion:              GoVersion{Package: "version", ImportPath: "example.com/foo/version"},
+			},
+		},
+		{
+			title:          "go version invalid variable",
+			configFileData: `{"goVersion": {"var": "app.Version"}}`,
+			wantErr:        "goVersion: invalid variable name: app.Version",
+		},
+		{
+			title:          "go version unknown option",
+			configFileData: `{"goVersion": {"pkg": "version"}}`,
+			wantErr:        `unknown config option "goVersion.pkg"`,
+		},
 		{
 			title:          "default config",
 			configFileData: `{}`,
@@ -202,3 +638,309 @@ func TestConfig_ParseJSON(t *testing.T) {
 		})
 	}
 }
+
+func boolPtr(b bool) *bool                              { return &b }
+func incrementPtr(i mapper.Increment) *mapper.Increment { return &i }
+func stringPtr(s string) *string                        { return &s }
+func tablePtr(t mapper.Table) *mapper.Table             { return &t }
+
+func mustRegex(t *testing.T, pattern string) *commit.Regex {
+	t.Helper()
+	p, err := commit.NewRegex(pattern)
+	require.NoError(t, err)
+	return p
+}
+
+func TestConfig_ParseYAML(t *testing.T) {
+	tests := []struct {
+		title          string
+		configFileData string
+		wantErr        string
+		want           Config
+	}{
+		{
+			title:          "empty config",
+			configFileData: "",
+			want: Config{
+				RemoteName:      "origin",
+				VersionPrefix:   "v",
+				CommitTypeTable: mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title: "good config",
+			configFileData: `incrementMappings:
+  feat: minor
+  docs: none
+defaultIncrement: none
+incrementDirtyWorktree: patch
+excludeModules:
+  - foo/bar
+versionPrefix: ""
+`,
+			want: Config{
+				ExcludeModules:         []string{"foo/bar"},
+				RemoteName:             "origin",
+				VersionPrefix:          "",
+				DirtyWorktreeIncrement: mapper.IncrementPatch,
+				CommitTypeTable: mapper.NewTable(mapper.Mapper{
+					"feat": mapper.IncrementMinor,
+					"docs": mapper.IncrementNone,
+				}, mapper.IncrementNone),
+			},
+		},
+		{
+			title:          "invalid yaml",
+			configFileData: "incrementMappings: [",
+			wantErr:        "yaml: line 1: did not find expected node content",
+		},
+		{
+			title:          "unknown option",
+			configFileData: "versionPrefx: v\n",
+			wantErr:        `unknown config option "versionPrefx", did you mean "versionPrefix"?`,
+		},
+		{
+			title:          "release mapping",
+			configFileData: "incrementMappings:\n  release: minor\n",
+			wantErr:        "release mapping is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			cfg := NewDefaultConfig()
+
+			err := cfg.ParseYAML([]byte(tt.configFileData))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, cfg)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ParseTOML(t *testing.T) {
+	tests := []struct {
+		title          string
+		configFileData string
+		wantErr        string
+		want           Config
+	}{
+		{
+			title:          "empty config",
+			configFileData: "",
+			want: Config{
+				RemoteName:      "origin",
+				VersionPrefix:   "v",
+				CommitTypeTable: mapper.NewTable(nil, mapper.IncrementPatch),
+			},
+		},
+		{
+			title: "good config",
+			configFileData: `defaultIncrement = "none"
+incrementDirtyWorktree = "patch"
+excludeModules = ["foo/bar"]
+versionPrefix = ""
+
+[incrementMappings]
+feat = "minor"
+docs = "none"
+`,
+			want: Config{
+				ExcludeModules:         []string{"foo/bar"},
+				RemoteName:             "origin",
+				VersionPrefix:          "",
+				DirtyWorktreeIncrement: mapper.IncrementPatch,
+				CommitTypeTable: mapper.NewTable(mapper.Mapper{
+					"feat": mapper.IncrementMinor,
+					"docs": mapper.IncrementNone,
+				}, mapper.IncrementNone),
+			},
+		},
+		{
+			title:          "invalid toml",
+			configFileData: "versionPrefix = ",
+			wantErr:        "toml: line 0 (last key \"versionPrefix\"): unexpected EOF; expected value",
+		},
+		{
+			title:          "unknown option",
+			configFileData: `remote = "upstream"`,
+			wantErr:        `unknown config option "remote", did you mean "remoteName"?`,
+		},
+		{
+			title: "components",
+			configFileData: `[[components]]
+name = "frontend"
+tagPrefix = "frontend/v"
+
+[[components]]
+name = "service"
+paths = ["services/api"]
+`,
+			want: Config{
+				RemoteName:      "origin",
+				VersionPrefix:   "v",
+				CommitTypeTable: mapper.NewTable(nil, mapper.IncrementPatch),
+				Components: []Component{
+					{Name: "frontend", TagPrefix: "frontend/v"},
+					{Name: "service", Paths: []string{"services/api"}},
+				},
+			},
+		},
+		{
+			title:          "unknown component option",
+			configFileData: "[[components]]\nname = \"frontend\"\nprefix = \"frontend/v\"\n",
+			wantErr:        `unknown config option "components.0.prefix", did you mean "tagPrefix"?`,
+		},
+		{
+			title:          "invalid increment",
+			configFileData: `incrementDirtyWorktree = "major"`,
+			wantErr:        "major version increments are not allowed for dirty worktrees",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+			cfg := NewDefaultConfig()
+
+			err := cfg.ParseTOML([]byte(tt.configFileData))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, cfg)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ParseFile(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"gotagger.json":  `{"versionPrefix": "json-"}`,
+		".gotagger.yaml": `versionPrefix: yaml-`,
+		".gotagger.yml":  `versionPrefix: yml-`,
+		".gotagger.toml": `versionPrefix = "toml-"`,
+	}
+
+	for name, data := range files {
+		fn := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(fn, []byte(data), 0600))
+
+		cfg := NewDefaultConfig()
+		if assert.NoError(t, cfg.ParseFile(fn), name) {
+			assert.Equal(t, strings.TrimPrefix(filepath.Ext(name), ".")+"-", cfg.VersionPrefix, name)
+		}
+	}
+
+	fn := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(fn, []byte(`incrementDirtyWorktree: foo`), 0600))
+
+	cfg := NewDefaultConfig()
+	assert.EqualError(t, cfg.ParseFile(fn), "invalid config file "+fn+": invalid dirty worktree increment: foo")
+}
+
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub", "dir")
+	require.NoError(t, os.MkdirAll(sub, 0700))
+
+	// no config file
+	if fn, err := FindConfigFile(sub, root); assert.NoError(t, err) {
+		assert.Empty(t, fn)
+	}
+
+	// config in the root
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gotagger.toml"), []byte(""), 0600))
+	if fn, err := FindConfigFile(sub, root); assert.NoError(t, err) {
+		assert.Equal(t, filepath.Join(root, ".gotagger.toml"), fn)
+	}
+
+	// closest config wins
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", ".gotagger.yaml"), []byte(""), 0600))
+	if fn, err := FindConfigFile(sub, root); assert.NoError(t, err) {
+		assert.Equal(t, filepath.Join(root, "sub", ".gotagger.yaml"), fn)
+	}
+
+	// json takes precedence within a directory
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "gotagger.json"), []byte(""), 0600))
+	if fn, err := FindConfigFile(sub, root); assert.NoError(t, err) {
+		assert.Equal(t, filepath.Join(root, "sub", "gotagger.json"), fn)
+	}
+
+	// do not search above root
+	if fn, err := FindConfigFile(sub, filepath.Join(root, "sub", "dir")); assert.NoError(t, err) {
+		assert.Empty(t, fn)
+	}
+}
+
+func TestConfig_Options(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.ParseJSON([]byte(`{
+	"defaultIncrement": "none",
+	"incrementDirtyWorktree": "minor",
+	"incrementMappings": {"feat": "minor", "fix": "patch"},
+	"paths": ["foo"],
+	"pushTag": true,
+	"remoteName": "upstream",
+	"versionPrefix": "",
+	"tagMessage": "{{.Tag}}",
+	"modules": {"foo": {"incrementMappings": {"fix": "minor"}, "incrementPreReleaseMinor": true}},
+	"components": [{"name": "web", "paths": ["web", "assets"], "tagPrefix": "web-", "defaultIncrement": "minor", "versionStrategy": "calver"}],
+	"versionStrategy": "calver",
+	"calverFormat": "YY.0M.MICRO",
+	"versionFiles": [{"path": "package.json", "key": "version", "component": "web"}],
+	"goVersion": {"var": "AppVersion", "importPath": "example.com/foo"},
+	"commitParser": "gitmoji",
+	"gitmojiTypes": {":art:": "style"},
+	"ignoreMerges": true,
+	"firstParent": true,
+	"ignoreAuthors": ["ci@example.com"],
+	"moduleValidation": "unreleased"
+}`)))
+
+	options := cfg.Options()
+
+	// every config option has a value
+	for name := range configOptions() {
+		assert.Contains(t, options, name)
+	}
+	assert.Len(t, options, len(configOptions()))
+
+	assert.Equal(t, "none", options["defaultIncrement"])
+	assert.Equal(t, "minor", options["incrementDirtyWorktree"])
+	assert.Equal(t, map[string]string{"feat": "minor", "fix": "patch"}, options["incrementMappings"])
+
+	// the options round-trip through a config file
+	data, err := json.Marshal(options)
+	require.NoError(t, err)
+
+	got := NewDefaultConfig()
+	if assert.NoError(t, got.ParseJSON(data)) {
+		assert.Equal(t, cfg, got)
+	}
+}
+
+func TestFileOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	fn := filepath.Join(dir, ".gotagger.toml")
+	require.NoError(t, os.WriteFile(fn, []byte("versionPrefix = \"\"\npushTag = true\n\n[incrementMappings]\nfeat = \"minor\"\n"), 0600))
+
+	if options, err := FileOptions(fn); assert.NoError(t, err) {
+		assert.Equal(t, []string{"incrementMappings", "pushTag", "versionPrefix"}, options)
+	}
+
+	fn = filepath.Join(dir, "gotagger.json")
+	require.NoError(t, os.WriteFile(fn, []byte("{"), 0600))
+
+	_, err := FileOptions(fn)
+	assert.EqualError(t, err, "invalid config file "+fn+": unexpected end of JSON input")
+}
@@ -0,0 +1,136 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Output formats of versions.
+const (
+	// FormatSemVer renders versions as semantic versions: 1.2.0-rc.1+build.5.
+	FormatSemVer = "semver"
+
+	// FormatPEP440 renders versions for python packages: 1.2.0rc1+build.5.
+	FormatPEP440 = "pep440"
+
+	// FormatMaven renders versions for maven artifacts: 1.2.0-rc-1.
+	FormatMaven = "maven"
+
+	// FormatDebian renders versions for debian packages: 1.2.0~rc.1+build.5.
+	FormatDebian = "debian"
+)
+
+// OutputFormats are the valid output formats.
+var OutputFormats = []string{FormatSemVer, FormatPEP440, FormatMaven, FormatDebian}
+
+var (
+	pep440PreRelease = regexp.MustCompile(`^([a-zA-Z]+)[.-]?(\d*)$`)
+	pep440Separators = strings.NewReplacer("-", ".", "_", ".")
+)
+
+// RenderVersion renders the semantic version version in format.
+//
+// The release part of version, such as 1.2.0, is kept as is,
+// so calendar versions keep their zero-padding.
+func RenderVersion(version, format string) (string, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	// the release part is everything before the pre-release and metadata
+	release := strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(release, "-+"); i >= 0 {
+		release = release[:i]
+	}
+
+	switch format {
+	case "", FormatSemVer:
+		return version, nil
+	case FormatPEP440:
+		return renderPEP440(release, v)
+	case FormatMaven:
+		return renderMaven(release, v), nil
+	case FormatDebian:
+		return renderDebian(release, v), nil
+	default:
+		return "", fmt.Errorf("invalid output format: %s", format)
+	}
+}
+
+// renderPEP440 renders v as a PEP 440 version.
+//
+// Pre-releases must be alpha, beta, or release candidates, or development
+// or post releases, such as rc.1, beta2, or dev.3.
+// Build metadata becomes a local version label.
+func renderPEP440(release string, v *semver.Version) (string, error) {
+	s := release
+	if pre := v.Prerelease(); pre != "" {
+		m := pep440PreRelease.FindStringSubmatch(pre)
+		if m == nil {
+			return "", fmt.Errorf("cannot render pre-release %s as a PEP 440 version", pre)
+		}
+
+		n := m[2]
+		if n == "" {
+			n = "0"
+		}
+
+		switch strings.ToLower(m[1]) {
+		case "a", "alpha":
+			s += "a" + n
+		case "b", "beta":
+			s += "b" + n
+		case "c", "rc", "pre", "preview":
+			s += "rc" + n
+		case "dev":
+			s += ".dev" + n
+		case "post", "rev", "r":
+			s += ".post" + n
+		default:
+			return "", fmt.Errorf("cannot render pre-release %s as a PEP 440 version", pre)
+		}
+	}
+
+	if meta := v.Metadata(); meta != "" {
+		s += "+" + pep440Separators.Replace(meta)
+	}
+
+	return s, nil
+}
+
+// renderMaven renders v as a maven version.
+//
+// The identifiers of the pre-release are separated by hyphens,
+// and build metadata is dropped because maven has no equivalent.
+func renderMaven(release string, v *semver.Version) string {
+	if pre := v.Prerelease(); pre != "" {
+		return release + "-" + strings.ReplaceAll(pre, ".", "-")
+	}
+
+	return release
+}
+
+// renderDebian renders v as the upstream version of a debian package.
+//
+// Pre-releases follow a tilde, so they sort before the release,
+// and build metadata follows a plus.
+// Hyphens are replaced by periods, since they separate the debian revision.
+func renderDebian(release string, v *semver.Version) string {
+	s := release
+	if pre := v.Prerelease(); pre != "" {
+		s += "~" + strings.ReplaceAll(pre, "-", ".")
+	}
+
+	if meta := v.Metadata(); meta != "" {
+		s += "+" + strings.ReplaceAll(meta, "-", ".")
+	}
+
+	return s
+}
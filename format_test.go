@@ -0,0 +1,52 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		format  string
+		want    string
+		wantErr string
+	}{
+		{"1.2.0-rc.1+build.5", "", "1.2.0-rc.1+build.5", ""},
+		{"v1.2.0-rc.1", FormatSemVer, "v1.2.0-rc.1", ""},
+		{"1.2.0", FormatPEP440, "1.2.0", ""},
+		{"v1.2.0", FormatPEP440, "1.2.0", ""},
+		{"1.2.0-rc.1", FormatPEP440, "1.2.0rc1", ""},
+		{"1.2.0-rc1", FormatPEP440, "1.2.0rc1", ""},
+		{"1.2.0-alpha", FormatPEP440, "1.2.0a0", ""},
+		{"1.2.0-beta.2", FormatPEP440, "1.2.0b2", ""},
+		{"1.2.0-dev.3", FormatPEP440, "1.2.0.dev3", ""},
+		{"1.2.0-post.1", FormatPEP440, "1.2.0.post1", ""},
+		{"1.2.0+build-5", FormatPEP440, "1.2.0+build.5", ""},
+		{"2026.01.3", FormatPEP440, "2026.01.3", ""},
+		{"1.2.0-snapshot.1", FormatPEP440, "", "cannot render pre-release snapshot.1 as a PEP 440 version"},
+		{"1.2.0-rc.1.2", FormatPEP440, "", "cannot render pre-release rc.1.2 as a PEP 440 version"},
+		{"1.2.0", FormatMaven, "1.2.0", ""},
+		{"1.2.0-rc.1+build.5", FormatMaven, "1.2.0-rc-1", ""},
+		{"1.2.0", FormatDebian, "1.2.0", ""},
+		{"1.2.0-rc.1", FormatDebian, "1.2.0~rc.1", ""},
+		{"1.2.0-pre-1+build-5", FormatDebian, "1.2.0~pre.1+build.5", ""},
+		{"1.2.0", "rpm", "", "invalid output format: rpm"},
+		{"foo", FormatPEP440, "", "Invalid Semantic Version"},
+	}
+
+	for _, tt := range tests {
+		got, err := RenderVersion(tt.version, tt.format)
+		if tt.wantErr == "" {
+			if assert.NoError(t, err, tt.version) {
+				assert.Equal(t, tt.want, got, tt.version)
+			}
+		} else {
+			assert.EqualError(t, err, tt.wantErr)
+		}
+	}
+}
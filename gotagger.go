@@ -6,7 +6,9 @@ package gotagger
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -26,18 +28,30 @@ const (
 	goModSep       = "/"
 	head           = "HEAD"
 	rootModulePath = "."
+
+	// the token of the footer that excludes a commit from version calculation
+	releaseIgnoreFooter = "Release-Ignore"
+
+	// the token of the footer that lists the modules of a release commit
+	modulesFooter = "Modules"
+
+	// the Modules footer entries that reference every module with unreleased changes
+	allChangedModules     = "*"
+	changedModulesKeyword = "changed"
 )
 
 var (
 	ErrNoSubmodule = errors.New("no submodule found")
 	ErrNotRelease  = errors.New("HEAD is not a release commit")
+	ErrTagConflict = errors.New("conflicting tags found")
 )
 
 type Gotagger struct {
 	Config Config
 
-	repo   *git.Repository
-	logger logr.Logger
+	repo     *git.Repository
+	logger   logr.Logger
+	warnings io.Writer
 }
 
 func New(path string) (*Gotagger, error) {
@@ -47,9 +61,10 @@ func New(path string) (*Gotagger, error) {
 	}
 
 	return &Gotagger{
-		Config: NewDefaultConfig(),
-		logger: logr.Discard(),
-		repo:   r,
+		Config:   NewDefaultConfig(),
+		logger:   logr.Discard(),
+		repo:     r,
+		warnings: io.Discard,
 	}, nil
 }
 
@@ -67,7 +82,12 @@ func (g *Gotagger) ModuleVersions(names ...string) ([]string, error) {
 		return nil, err
 	}
 
-	return g.versions(modules, nil)
+	releases, err := g.versions(modules, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.outputVersions(releases)
 }
 
 func (g *Gotagger) SetLogger(l logr.Logger) {
@@ -79,6 +99,43 @@ func (g *Gotagger) SetLogger(l logr.Logger) {
 	g.repo.SetLogger(g.logger.WithName("git"))
 }
 
+// SetWarningOutput sets the destination of warnings, such as changed modules
+// that a release commit does not release. Warnings are discarded by default.
+func (g *Gotagger) SetWarningOutput(w io.Writer) {
+	g.warnings = w
+}
+
+// LoadConfig searches dir and each of its parents, up to the top-level directory
+// of the repository, for a config file, and parses the first one found into
+// g.Config. See FindConfigFile.
+//
+// LoadConfig returns the path of the config file it loaded,
+// or the empty string if none was found.
+func (g *Gotagger) LoadConfig(dir string) (string, error) {
+	root, err := g.repo.Toplevel()
+	if err != nil {
+		return "", err
+	}
+
+	// resolve symlinks so dir and root are comparable
+	if d, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = d
+	}
+
+	fn, err := FindConfigFile(dir, root)
+	if err != nil || fn == "" {
+		g.logger.Info("no config file found", "dir", dir, "root", root)
+		return "", err
+	}
+
+	g.logger.Info("loading config file", "path", fn)
+	if err := g.Config.ParseFile(fn); err != nil {
+		return "", err
+	}
+
+	return fn, nil
+}
+
 // TagRepo determines the current version of the repository by parsing the commit
 // history since the previous release and returns that version. Depending
 // on the CreateTag and PushTag configuration options tags may be created and
@@ -87,6 +144,14 @@ func (g *Gotagger) SetLogger(l logr.Logger) {
 // If the current commit contains one or more Modules footers, then tags are
 // created for each module listed. In this case if the root module is not
 // explicitly included in a Modules footer then it will not be included.
+//
+// If Components are configured, then tags are only created for the components
+// that changed since their previous release, and only their versions are returned.
+//
+// Before any tags are created, TagRepo checks that they do not conflict with
+// existing local tags, or with tags in the remote repository if it exists.
+// Tags that already point to the current commit are not an error,
+// so running TagRepo again on a tagged commit is safe.
 func (g *Gotagger) TagRepo() ([]string, error) {
 	// get all modules, if any, unless we're explicitly ignoring them
 	var modules []module
@@ -98,8 +163,8 @@ func (g *Gotagger) TagRepo() ([]string, error) {
 		modules = m
 	}
 
-	// get the current HEAD commit
-	c, err := g.repo.Head()
+	// get the commit we are tagging
+	c, err := g.commit(g.rev())
 	if err != nil {
 		return nil, err
 	}
@@ -107,27 +172,55 @@ func (g *Gotagger) TagRepo() ([]string, error) {
 	var commitModules []module
 	if len(modules) > 0 {
 		// there are go modules, so validate that if this is a release commit it is correct
-		commitModules, err = extractCommitModules(c, modules)
+		var unreleased []module
+		commitModules, unreleased, err = g.extractCommitModules(c, modules)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := g.validateCommit(c, modules, commitModules); err != nil {
+		if err := g.validateCommit(c, modules, commitModules, unreleased); err != nil {
 			return nil, err
 		}
 	}
 
-	versions, err := g.versions(modules, commitModules)
+	releases, err := g.versions(modules, commitModules)
 	if err != nil {
 		return nil, err
 	}
 
 	// determine if we should create and push a tag or not
-	if (g.Config.Force || c.Type == mapper.TypeRelease) && g.Config.CreateTag {
+	tagging := (g.Config.Force || mapper.IsRelease(c.Type)) && g.Config.CreateTag
+	if tagging {
+		// components that have not changed keep their previous release,
+		// so they are neither tagged nor returned
+		releases = changedComponents(releases)
+	}
+
+	versions, err := g.outputVersions(releases)
+	if err != nil {
+		return nil, err
+	}
+
+	if tagging {
+		// make sure none of the tags conflict with existing tags
+		create, push, err := g.checkTags(c.Hash, releases)
+		if err != nil {
+			return nil, err
+		}
+
 		// create tag
-		tags := make([]string, 0, len(versions))
-		for _, ver := range versions {
-			if err := g.repo.CreateTag(c.Hash, ver, "", false); err != nil {
+		releasesByTag := make(map[string]release, len(releases))
+		for _, r := range releases {
+			releasesByTag[r.tag()] = r
+		}
+
+		tags := make([]string, 0, len(create))
+		for _, ver := range create {
+			opts, err := g.tagOptions(releasesByTag[ver])
+			if err == nil {
+				err = g.repo.CreateTag(c.Hash, ver, opts)
+			}
+			if err != nil {
 				// clean up tags we already created
 				if terr := g.repo.DeleteTags(tags); terr != nil {
 					err = fmt.Errorf("%w\n%s", err, terr)
@@ -138,8 +231,8 @@ func (g *Gotagger) TagRepo() ([]string, error) {
 		}
 
 		// push tags
-		if g.Config.PushTag {
-			if err := g.repo.PushTags(tags, g.Config.RemoteName); err != nil {
+		if g.Config.PushTag && len(push) > 0 {
+			if err := g.repo.PushTags(push, g.Config.RemoteName); err != nil {
 				// currently pushes are not atomic so some of the tags may be
 				// pushed while others fail. we delete all of the local tags to
 				// be safe
@@ -154,6 +247,38 @@ func (g *Gotagger) TagRepo() ([]string, error) {
 	return versions, nil
 }
 
+// remoteTags returns the tags of the remote repository, or nil if tags are not
+// pushed and the remote does not exist.
+//
+// Checking the remote before creating tags means a release can be pushed later.
+// Unless tags are pushed, a remote that cannot be reached is only a warning.
+func (g *Gotagger) remoteTags() (map[string]string, error) {
+	remote := g.Config.RemoteName
+	if !g.Config.PushTag {
+		remotes, err := g.repo.Remotes()
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, r := range remotes {
+			found = found || r == remote
+		}
+		if !found {
+			g.logger.Info("remote not found, not checking remote tags", "remote", remote)
+			return nil, nil
+		}
+	}
+
+	tags, err := g.repo.RemoteTags(remote)
+	if err != nil && !g.Config.PushTag {
+		fmt.Fprintln(g.warnings, "warning: could not check the tags of remote", remote+":", err)
+		return nil, nil
+	}
+
+	return tags, err
+}
+
 // Version returns the current version for the repository.
 //
 // In a repository that contains multiple go modules, this returns the version
@@ -171,15 +296,98 @@ func (g *Gotagger) Version() (string, error) {
 		modules = m
 	}
 
-	versions, err := g.versions(modules, nil)
+	releases, err := g.versions(modules, nil)
 	if err != nil {
 		return "", err
 	}
 
 	// only return the first version
+	versions, err := g.outputVersions(releases[:1])
+	if err != nil {
+		return "", err
+	}
+
 	return versions[0], nil
 }
 
+// checkTags compares the tags for releases with the existing local tags,
+// and the tags in the remote repository if it exists.
+//
+// It returns the tags that need to be created and the tags that need to be
+// pushed. Tags that already point to hash are skipped, so tagging a commit
+// that is already tagged is not an error.
+func (g *Gotagger) checkTags(hash string, releases []release) (create, push []string, err error) {
+	g.logger.Info("checking for conflicting tags")
+
+	localTags, err := g.repo.TagCommits()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remoteTags, err := g.remoteTags()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var conflicts []string
+	for _, r := range releases {
+		tag := r.tag()
+		logger := g.logger.WithValues("tag", tag)
+
+		if tagHash, ok := localTags[tag]; !ok {
+			create = append(create, tag)
+		} else if tagHash != hash {
+			conflicts = append(conflicts, fmt.Sprintf("tag %s already exists on commit %s", tag, tagHash))
+			continue
+		} else {
+			logger.Info("tag already exists")
+		}
+
+		if remoteTags == nil {
+			continue
+		}
+
+		if tagHash, ok := remoteTags[tag]; !ok {
+			push = append(push, tag)
+		} else if tagHash != hash {
+			conflicts = append(conflicts, fmt.Sprintf("tag %s already exists on remote %s on commit %s", tag, g.Config.RemoteName, tagHash))
+			continue
+		} else {
+			logger.Info("tag already exists on remote", "remote", g.Config.RemoteName)
+		}
+
+		// look for newer versions on the remote that have not been fetched
+		version, err := semver.NewVersion(r.version)
+		if err != nil {
+			return nil, nil, err
+		}
+		for remoteTag := range remoteTags {
+			if _, ok := localTags[remoteTag]; ok || !strings.HasPrefix(remoteTag, r.prefix) {
+				continue
+			}
+
+			tver, err := semver.NewVersion(strings.TrimPrefix(remoteTag, r.prefix))
+			if err != nil || !tver.GreaterThan(version) {
+				continue
+			}
+
+			// modules only conflict with versions of the same major version
+			if r.module.name != "" && tver.Major() != version.Major() {
+				continue
+			}
+
+			conflicts = append(conflicts, fmt.Sprintf("remote %s has newer version %s than %s: fetch tags and try again", g.Config.RemoteName, remoteTag, tag))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, nil, fmt.Errorf("%w:\n%s", ErrTagConflict, strings.Join(conflicts, "\n"))
+	}
+
+	return create, push, nil
+}
+
 func (g *Gotagger) findAllModules(include []string) (modules []module, err error) {
 	g.logger.Info("finding modules")
 
@@ -199,7 +407,90 @@ func (g *Gotagger) findAllModules(include []string) (modules []module, err error
 		pathexclude[i] = normalizePath(name)
 	}
 
-	// walk root and find all modules
+	goMods, err := g.findGoMods()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, goModFile := range goMods {
+		logger := g.logger.WithValues("path", goModFile.path)
+
+		// ignore go.mods that don't parse a module path
+		modName := modfile.ModulePath(goModFile.data)
+		if modName == "" {
+			continue
+		}
+
+		modPath := filepath.Dir(goModFile.path)
+		logger = logger.WithValues("module", modName, "modulePath", modPath)
+
+		// ignore module if it is not an included one
+		if _, include := modinclude[modName]; !include && len(modinclude) > 0 {
+			logger.Info("ignoring module that is not explicitly included")
+			continue
+		}
+
+		// ignore module if it is excluded by name
+		if _, excludeName := modexclude[modName]; excludeName {
+			logger.Info("ignoring excluded module")
+			continue
+		}
+
+		// normalize module path to ease comparisons
+		normPath := normalizePath(modPath)
+		excluded := false
+		for _, exclude := range pathexclude {
+			// see if an exclude is a prefix of normPath
+			if strings.HasPrefix(normPath, exclude) {
+				excluded = true
+				break
+			}
+		}
+		if excluded {
+			logger.Info("ignoring excluded module path")
+			continue
+		}
+
+		// derive modPrefix from modPath
+		modPrefix := filepath.ToSlash(modPath)
+		if modPrefix == rootModulePath {
+			modPrefix = ""
+		} else {
+			// determine the major version prefix for this module
+			major := strings.TrimPrefix(versionRegex.FindString(modName), goModSep)
+
+			// strip trailing major version directory from prefix
+			modPrefix = strings.TrimSuffix(modPrefix, major)
+			if modPrefix != "" && !strings.HasSuffix(modPrefix, goModSep) {
+				modPrefix += goModSep
+			}
+		}
+
+		logger.Info("adding moddule", "modulePrefix", modPrefix)
+		modules = append(modules, module{modPath, modName, modPrefix})
+	}
+
+	if len(modules) > 0 && len(g.Config.Paths) > 0 {
+		err = errors.New("cannot use path filtering with go modules")
+	}
+	if len(modules) > 0 && len(g.Config.Components) > 0 {
+		err = errors.New("cannot use components with go modules")
+	}
+
+	sortByPath(modules).Sort()
+	return
+}
+
+// findGoMods returns all of the go.mod files in the repository.
+//
+// If the configured revision is HEAD, then the worktree is searched.
+// Otherwise, the go.mod files are read from the revision.
+func (g *Gotagger) findGoMods() (goMods []goModFile, err error) {
+	if !g.isHead() {
+		return g.findGoModsAt(g.rev())
+	}
+
+	// walk root and find all go.mod files
 	err = filepath.Walk(g.repo.Path, func(pth string, info os.FileInfo, err error) error {
 		// bail on errors
 		if err != nil {
@@ -211,8 +502,7 @@ func (g *Gotagger) findAllModules(include []string) (modules []module, err error
 		// ignore directories
 		if info.IsDir() {
 			// don't recurse into directories that start with '.', '_', or are named 'testdata'
-			dirname := info.Name()
-			if dirname != "." && (strings.HasPrefix(dirname, ".") || strings.HasPrefix(dirname, "_") || dirname == "testdata") {
+			if dirname := info.Name(); dirname != "." && isIgnoredDir(dirname) {
 				logger.Info("not recursing into directory: ignored by default")
 				return filepath.SkipDir
 			}
@@ -233,66 +523,51 @@ func (g *Gotagger) findAllModules(include []string) (modules []module, err error
 				return err
 			}
 
-			// ignore go.mods that don't parse a module path
-			if modName := modfile.ModulePath(data); modName != "" {
-				modPath := filepath.Dir(relPath)
-				logger := logger.WithValues("module", modName, "modulePath", modPath)
+			goMods = append(goMods, goModFile{relPath, data})
+		}
 
-				// ignore module if it is not an included one
-				if _, include := modinclude[modName]; !include && len(modinclude) > 0 {
-					logger.Info("ignoring module that is not explicitly included")
-					return nil
-				}
+		return nil
+	})
 
-				// ignore module if it is excluded by name
-				if _, excludeName := modexclude[modName]; excludeName {
-					logger.Info("ignoring excluded module")
-					// ignore this module
-					return nil
-				}
+	return
+}
 
-				// normalize module path to ease comparisons
-				normPath := normalizePath(modPath)
-				for _, exclude := range pathexclude {
-					// see if an exclude is a prefix of normPath
-					if strings.HasPrefix(normPath, exclude) {
-						logger.Info("ignoring excluded module path")
-						return nil
-					}
-				}
+// findGoModsAt returns all of the go.mod files in the tree of rev.
+func (g *Gotagger) findGoModsAt(rev string) ([]goModFile, error) {
+	files, err := g.repo.Files(rev)
+	if err != nil {
+		return nil, err
+	}
 
-				// derive modPrefix from modPath
-				modPrefix := filepath.ToSlash(modPath)
-				if modPrefix == rootModulePath {
-					modPrefix = ""
-				} else {
-					// determine the major version prefix for this module
-					major := strings.TrimPrefix(versionRegex.FindString(modName), goModSep)
-
-					// strip trailing major version directory from prefix
-					modPrefix = strings.TrimSuffix(modPrefix, major)
-					if modPrefix != "" && !strings.HasSuffix(modPrefix, goModSep) {
-						modPrefix += goModSep
-					}
-				}
+	var goMods []goModFile
+outer:
+	for _, file := range files {
+		if file != goMod && !strings.HasSuffix(file, goModSep+goMod) {
+			continue
+		}
 
-				logger.Info("adding moddule", "modulePrefix", modPrefix)
-				modules = append(modules, module{modPath, modName, modPrefix})
+		// skip go.mod files in directories that start with '.', '_', or are named 'testdata'
+		dirs := strings.Split(file, goModSep)
+		for _, dirname := range dirs[:len(dirs)-1] {
+			if isIgnoredDir(dirname) {
+				continue outer
 			}
 		}
 
-		return nil
-	})
+		g.logger.Info("found go module", "path", file, "rev", rev)
+		data, err := g.repo.ReadFile(rev, file)
+		if err != nil {
+			return nil, err
+		}
 
-	if len(modules) > 0 && len(g.Config.Paths) > 0 {
-		err = errors.New("cannot use path filtering with go modules")
+		goMods = append(goMods, goModFile{filepath.FromSlash(file), data})
 	}
 
-	sortByPath(modules).Sort()
-	return
+	return goMods, nil
 }
 
 func (g *Gotagger) incrementVersion(v *semver.Version, commits []git.Commit) (string, error) {
+	strategy := g.strategy()
 
 	// If this is the latest tagged commit, then return
 	if len(commits) > 0 {
@@ -300,18 +575,21 @@ func (g *Gotagger) incrementVersion(v *semver.Version, commits []git.Commit) (st
 		switch change {
 		case mapper.IncrementMajor:
 			g.logger.Info("incrementing major version")
-			return v.IncMajor().String(), nil
 		case mapper.IncrementMinor:
 			g.logger.Info("incrementing minor version")
-			return v.IncMinor().String(), nil
 		case mapper.IncrementPatch:
 			g.logger.Info("incrementing patch version")
-			return v.IncPatch().String(), nil
 		default:
 			g.logger.Info("not incrementing version")
-			return v.String(), nil
 		}
+
+		return g.nextVersion(v, change)
 	} else {
+		// the worktree only matters when versioning HEAD
+		if !g.isHead() {
+			return strategy.Format(v), nil
+		}
+
 		isDirty, err := g.repo.IsDirty()
 		if err != nil {
 			return "", err
@@ -320,34 +598,58 @@ func (g *Gotagger) incrementVersion(v *semver.Version, commits []git.Commit) (st
 		switch {
 		case isDirty && g.Config.DirtyWorktreeIncrement == mapper.IncrementMinor:
 			g.logger.Info("incrementing minor version due to dirty worktree")
-			return v.IncMinor().String(), nil
+			return g.nextVersion(v, mapper.IncrementMinor)
 		case isDirty && g.Config.DirtyWorktreeIncrement == mapper.IncrementPatch:
 			g.logger.Info("incrementing patch version due to dirty worktree")
-			return v.IncPatch().String(), nil
+			return g.nextVersion(v, mapper.IncrementPatch)
 		default:
-			return v.String(), nil
+			return strategy.Format(v), nil
 		}
 	}
 }
 
+// nextVersion returns the version that follows v for the increment inc,
+// formatted by the versioning strategy.
+func (g *Gotagger) nextVersion(v *semver.Version, inc mapper.Increment) (string, error) {
+	strategy := g.strategy()
+
+	next, err := strategy.Next(v, inc)
+	if err != nil {
+		return "", err
+	}
+
+	return strategy.Format(next), nil
+}
+
+// strategy returns the configured versioning strategy, defaulting to SemVer.
+func (g *Gotagger) strategy() VersionStrategy {
+	if g.Config.VersionStrategy == nil {
+		return SemVer{}
+	}
+
+	return g.Config.VersionStrategy
+}
+
 func (g *Gotagger) latest(tags []string, prefix string) (latest *semver.Version, hash string, err error) {
 	logger := g.logger.WithValues("prefix", prefix)
 	logger.Info("finding latest tag")
 
-	latest = &semver.Version{}
-	for _, tag := range tags {
-		tagName := strings.TrimPrefix(tag, prefix)
-		if tver, err := semver.NewVersion(tagName); err == nil && latest.LessThan(tver) {
-			g.logger.Info("found newer tag", "tag", tver)
-			hash, err = g.repo.RevParse(tag + "^{commit}")
-			if err != nil {
-				return nil, "", err
-			}
-			latest = tver
-		}
+	latestTag, err := g.selectLatest(g.pathTagVersions(tags, prefix))
+	if err != nil {
+		return nil, "", err
 	}
 
-	return
+	// if there were no tags, then return 0.0.0
+	if latestTag.version == nil {
+		return &semver.Version{}, "", nil
+	}
+
+	hash, err = g.repo.RevParse(latestTag.tag + "^{commit}")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return latestTag.version, hash, nil
 }
 
 // latestModule returns the latest version of m and the hash of the commit
@@ -356,6 +658,36 @@ func (g *Gotagger) latestModule(tags []string, m module) (*semver.Version, strin
 	logger := g.logger.WithValues("module", m.name, "module_prefix", m.prefix, "module_path", m.path)
 	logger.Info("finding latest tag for module")
 
+	moduleVersion, candidates, err := g.moduleTagVersions(tags, m)
+	if err != nil {
+		return nil, "", err
+	}
+
+	latestTag, err := g.selectLatest(candidates)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// if there were no tags, then return the base module version
+	if latestTag.version == nil {
+		return moduleVersion, "", nil
+	}
+
+	hash, err := g.repo.RevParse(latestTag.tag + "^{commit}")
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.Info("found latest tag", "tag", latestTag.version, "commit", hash)
+	return latestTag.version, hash, nil
+}
+
+// moduleTagVersions returns the base version of m,
+// and the versions parsed from the tags that belong to m.
+//
+// Tags belong to m if they have the module prefix of m,
+// and their major version matches the major version of m.
+func (g *Gotagger) moduleTagVersions(tags []string, m module) (*semver.Version, []tagVersion, error) {
 	majorVersion := strings.TrimPrefix(versionRegex.FindString(m.name), goModSep)
 	if majorVersion == "" {
 		majorVersion = "v0"
@@ -363,7 +695,7 @@ func (g *Gotagger) latestModule(tags []string, m module) (*semver.Version, strin
 
 	moduleVersion, err := semver.NewVersion(majorVersion + ".0.0")
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	_maximumVersion := moduleVersion.IncMajor()
@@ -371,39 +703,29 @@ func (g *Gotagger) latestModule(tags []string, m module) (*semver.Version, strin
 		_maximumVersion = _maximumVersion.IncMajor()
 	}
 	maximumVersion := &_maximumVersion
-	logger.Info("ignoring modules greater than " + g.Config.VersionPrefix + maximumVersion.String())
+	g.logger.Info("ignoring modules greater than "+g.Config.VersionPrefix+maximumVersion.String(), "module", m.name)
 
-	var latestVersion *semver.Version
-	var latestTag string
+	var candidates []tagVersion
 	for _, tag := range tags {
 		// strip the module prefix from the tag so we can parse it as a semver
 		tagName := strings.TrimPrefix(tag, m.prefix)
-		// we want the highest version that is less than the next major version
 		tver, err := semver.NewVersion(tagName)
 		if err != nil {
-			continue
-		}
-		if tver.Compare(maximumVersion) < 0 && tver.Compare(moduleVersion) >= 0 {
-			if latestVersion == nil || latestVersion.LessThan(tver) {
-				logger.Info("found newer tag", "tag", tag)
-				latestVersion = tver
-				latestTag = tag
+			// semver only understands a "v" prefix,
+			// so strip any other version prefix
+			tver, err = semver.NewVersion(strings.TrimPrefix(tagName, g.Config.VersionPrefix))
+			if err != nil {
+				continue
 			}
 		}
-	}
-
-	// if there were no tags, then return the base module version
-	if latestVersion == nil {
-		return moduleVersion, "", nil
-	}
 
-	hash, err := g.repo.RevParse(latestTag + "^{commit}")
-	if err != nil {
-		return nil, "", err
+		// we want versions that are less than the next major version
+		if tver.Compare(maximumVersion) < 0 && tver.Compare(moduleVersion) >= 0 {
+			candidates = append(candidates, tagVersion{tag, tver})
+		}
 	}
 
-	logger.Info("found latest tag", "tag", latestVersion, "commit", hash)
-	return latestVersion, hash, nil
+	return moduleVersion, candidates, nil
 }
 
 func (g *Gotagger) parseCommits(cs []git.Commit, v *semver.Version) (vinc mapper.Increment) {
@@ -411,6 +733,11 @@ func (g *Gotagger) parseCommits(cs []git.Commit, v *semver.Version) (vinc mapper
 
 	for _, c := range cs {
 		logger := g.logger.WithValues("commit", c.Hash)
+		if reason := g.ignoreReason(c); reason != "" {
+			logger.Info("ignoring commit", "reason", reason, "author", c.Author.Email)
+			continue
+		}
+
 		inc := g.Config.CommitTypeTable.Get(c.Type)
 		if c.Breaking {
 			// ignore breaking if this is a 0.x.y version and PreMajor is set
@@ -443,7 +770,124 @@ func (g *Gotagger) parseCommits(cs []git.Commit, v *semver.Version) (vinc mapper
 	return vinc
 }
 
-func (g *Gotagger) validateCommit(c git.Commit, modules []module, commitModules []module) error {
+// ignoreReason returns why c is excluded from version calculation,
+// or the empty string if it is not.
+func (g *Gotagger) ignoreReason(c git.Commit) string {
+	for _, f := range c.Footers {
+		if strings.EqualFold(f.Title, releaseIgnoreFooter) && strings.EqualFold(strings.TrimSpace(f.Text), "true") {
+			return releaseIgnoreFooter + " footer"
+		}
+	}
+
+	email := strings.ToLower(c.Author.Email)
+	for _, pattern := range g.Config.IgnoreAuthors {
+		if ok, _ := path.Match(strings.ToLower(pattern), email); ok {
+			return "ignored author " + pattern
+		}
+	}
+
+	return ""
+}
+
+// selectLatest returns the highest version in candidates.
+//
+// If tag verification is enabled, then the signature of the selected tag is
+// verified. Depending on the IgnoreUnsignedTags option tags without a valid
+// signature are either skipped or an error.
+func (g *Gotagger) selectLatest(candidates []tagVersion) (tagVersion, error) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.GreaterThan(candidates[j].version)
+	})
+
+	verify := g.Config.VerifyTags || g.Config.IgnoreUnsignedTags
+	for _, candidate := range candidates {
+		logger := g.logger.WithValues("tag", candidate.tag)
+		if verify {
+			if err := g.repo.VerifyTag(candidate.tag); err != nil {
+				if !g.Config.IgnoreUnsignedTags {
+					return tagVersion{}, fmt.Errorf("tag %s does not have a valid signature: %w", candidate.tag, err)
+				}
+
+				logger.Info("ignoring tag without a valid signature")
+				continue
+			}
+		}
+
+		logger.Info("found newer tag")
+		return candidate, nil
+	}
+
+	return tagVersion{}, nil
+}
+
+// tagOptions returns the git.TagOptions used to create the tag for r.
+func (g *Gotagger) tagOptions(r release) (git.TagOptions, error) {
+	opts := git.TagOptions{
+		Sign:          g.Config.SignTags || g.Config.SigningKey != "",
+		SigningKey:    g.Config.SigningKey,
+		SigningFormat: g.Config.SigningFormat,
+		Lightweight:   g.Config.LightweightTags,
+	}
+
+	if g.Config.TagMessage != "" && !opts.Lightweight {
+		message, err := renderTagMessage(g.Config.TagMessage, newTagMessageData(r))
+		if err != nil {
+			return git.TagOptions{}, err
+		}
+		opts.Message = message
+	}
+
+	return opts, nil
+}
+
+// rev returns the revision gotagger is versioning.
+func (g *Gotagger) rev() string {
+	if g.Config.Rev == "" {
+		return head
+	}
+
+	return g.Config.Rev
+}
+
+// commit returns the commit at rev, parsed by the configured commit parser.
+func (g *Gotagger) commit(rev string) (git.Commit, error) {
+	return g.repo.Commit(rev, g.historyOptions())
+}
+
+// revList returns the commits from start to end that change paths,
+// parsed by the configured commit parser.
+// Merge commits are skipped or followed as configured.
+func (g *Gotagger) revList(start, end string, paths ...string) ([]git.Commit, error) {
+	return g.repo.RevList(start, end, g.historyOptions(), paths...)
+}
+
+// tags returns the tags reachable from rev that match prefixes.
+// In first-parent mode, tags on merged branches are not reachable.
+func (g *Gotagger) tags(rev string, prefixes ...string) ([]string, error) {
+	return g.repo.Tags(rev, g.historyOptions(), prefixes...)
+}
+
+// historyOptions returns the history options and commit parser of the config.
+func (g *Gotagger) historyOptions() git.HistoryOptions {
+	return git.HistoryOptions{
+		FirstParent: g.Config.FirstParent,
+		NoMerges:    g.Config.IgnoreMerges,
+		Parser:      g.Config.CommitParser,
+	}
+}
+
+// isHead returns true if gotagger is versioning HEAD.
+func (g *Gotagger) isHead() bool {
+	return g.rev() == head
+}
+
+// validateCommit validates the commitModules released by c.
+//
+// unreleased are the modules with unreleased changes found by extractCommitModules,
+// or nil if the Modules footer did not reference them. Modules released that way
+// are validated against their unreleased changes, since the release commit
+// does not have to change them.
+func (g *Gotagger) validateCommit(c git.Commit, modules []module, commitModules []module, unreleased []module) error {
 	logger := g.logger.WithValues("commit", c.Hash)
 
 	// if no modules were found, then skip validation
@@ -454,7 +898,11 @@ func (g *Gotagger) validateCommit(c git.Commit, modules []module, commitModules
 	// map modules by path for faster lookup
 	modulesByPath := mapModulesByPath(modules)
 
-	if c.Type == mapper.TypeRelease {
+	if mapper.IsRelease(c.Type) && (unreleased != nil || g.Config.ModuleValidation == ValidateUnreleased) {
+		return g.validateUnreleased(c, modules, commitModules, unreleased)
+	}
+
+	if mapper.IsRelease(c.Type) {
 		// generate a list of modules changed by this commit
 		var changedModules []module
 		for _, change := range c.Changes {
@@ -475,12 +923,42 @@ func (g *Gotagger) validateCommit(c git.Commit, modules []module, commitModules
 	return nil
 }
 
-func (g *Gotagger) versions(modules, commitModules []module) (versions []string, err error) {
+// validateUnreleased validates that each of the commitModules released by c
+// has unreleased changes, and warns about the modules with unreleased changes
+// that c does not release.
+//
+// The modules with unreleased changes are only found if unreleased is nil.
+func (g *Gotagger) validateUnreleased(c git.Commit, modules []module, commitModules []module, unreleased []module) error {
+	if unreleased == nil {
+		var err error
+		unreleased, err = g.changedModules(c, modules)
+		if err != nil {
+			return err
+		}
+	}
+
+	unchanged, missing := diffModules(commitModules, unreleased)
+	if len(missing) > 0 {
+		msg := "changed modules not released by commit: " + strings.Join(missing, ", ")
+		g.logger.Info(msg)
+		fmt.Fprintln(g.warnings, "warning:", msg)
+	}
+
+	if len(unchanged) > 0 {
+		return errors.New("module validation failed:\nmodules without unreleased changes: " + strings.Join(unchanged, ", "))
+	}
+
+	return nil
+}
+
+func (g *Gotagger) versions(modules, commitModules []module) (releases []release, err error) {
 	if len(modules) != 0 {
 		g.logger.Info("enforcing module versioning")
-		versions, err = g.versionsModules(modules, commitModules)
+		releases, err = g.versionsModules(modules, commitModules)
+	} else if len(g.Config.Components) != 0 {
+		releases, err = g.versionsComponents()
 	} else {
-		versions, err = g.versionsSimple()
+		releases, err = g.versionsSimple()
 	}
 
 	return
@@ -488,130 +966,235 @@ func (g *Gotagger) versions(modules, commitModules []module) (versions []string,
 
 var versionRegex = regexp.MustCompile(`/v\d+$`)
 
-func (g *Gotagger) versionsModules(modules []module, commitModules []module) ([]string, error) {
+func (g *Gotagger) versionsModules(modules []module, commitModules []module) ([]release, error) {
 	g.logger.Info("versioning modules")
 
+	// semantic import versioning requires semantic versions
+	if name := g.strategy().Name(); name != SemVerStrategy {
+		return nil, fmt.Errorf("cannot use the %s version strategy with go modules", name)
+	}
+
 	// if no commit modules, then get versions for all modules
 	if len(commitModules) == 0 {
 		commitModules = modules
 	}
 
-	versions := make([]string, len(commitModules))
+	releases := make([]release, len(commitModules))
 	for i, mod := range commitModules {
-		logger := g.logger.WithValues("module", mod.name)
-
-		// we determine the tag prefix by concatenating the module prefix, the
-		// version prefix, and the major version of this module.
-		// the major version is the version part of the module name
-		// (foo/v2, foo/v3) normalized to 'X.'
-		prefix := g.Config.VersionPrefix
-		if mod.prefix != "" {
-			prefix = mod.prefix + prefix
-		}
+		// apply any configuration overrides for this module
+		mg := g.forModule(mod)
 
-		// get tags that match the prefixes
-		tags, err := g.repo.Tags(head, prefix)
+		r, latest, err := mg.moduleUnreleased(mod, modules)
 		if err != nil {
 			return nil, err
 		}
-		logger.Info("found tags", "tags", tags)
 
-		// get latest commit for this module
-		latest, hash, err := g.latestModule(tags, mod)
+		r.version, err = mg.incrementVersion(latest, r.commits)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("could not increment version: %w", err)
 		}
 
-		// Find the commits between HEAD and latest
-		// that touched any path under the module.
-		// This list will need further filtering to deal with modules
-		// that are sub-directories of this module.
-		commits, err := g.repo.RevList(head, hash, mod.path)
+		releases[i] = r
+	}
+
+	return releases, nil
+}
+
+// moduleUnreleased returns the latest version of mod,
+// and a release with the tag prefix, previous version,
+// and commits since the latest version of mod.
+// The version of the release is not set.
+func (g *Gotagger) moduleUnreleased(mod module, modules []module) (release, *semver.Version, error) {
+	logger := g.logger.WithValues("module", mod.name)
+
+	// we determine the tag prefix by concatenating the module prefix, the
+	// version prefix, and the major version of this module.
+	// the major version is the version part of the module name
+	// (foo/v2, foo/v3) normalized to 'X.'
+	prefix := g.Config.VersionPrefix
+	if mod.prefix != "" {
+		prefix = mod.prefix + prefix
+	}
+
+	// get tags that match the prefixes
+	tags, err := g.tags(g.rev(), prefix)
+	if err != nil {
+		return release{}, nil, err
+	}
+	logger.Info("found tags", "tags", tags)
+
+	// get latest commit for this module
+	latest, hash, err := g.latestModule(tags, mod)
+	if err != nil {
+		return release{}, nil, err
+	}
+
+	// Find the commits between the revision and latest
+	// that touched any path under the module.
+	// This list will need further filtering to deal with modules
+	// that are sub-directories of this module.
+	commits, err := g.revList(g.rev(), hash, mod.path)
+	if err != nil {
+		return release{}, nil, fmt.Errorf("could not fetch commits %s..%s: %w", g.rev(), hash, err)
+	}
+
+	// group the commits by the modules they affected
+	commitsByModule := g.groupCommitsByModule(commits, modules)
+
+	return release{
+		module:   mod,
+		prefix:   prefix,
+		previous: g.previousVersion(latest, hash),
+		commits:  commitsByModule[mod],
+	}, latest, nil
+}
+
+// changedModules returns the modules with unreleased changes,
+// which are the modules that have commits since their latest version
+// other than the release commit c and ignored commits,
+// and whose version those commits change.
+func (g *Gotagger) changedModules(c git.Commit, modules []module) ([]module, error) {
+	var changed []module
+	for _, mod := range modules {
+		logger := g.logger.WithValues("module", mod.name)
+
+		mg := g.forModule(mod)
+		r, latest, err := mg.moduleUnreleased(mod, modules)
 		if err != nil {
-			return nil, fmt.Errorf("could not fetch commits HEAD..%s: %w", hash, err)
+			return nil, err
 		}
 
-		// group the commits by the modules they affected
-		commitsByModule := g.groupCommitsByModule(commits, modules)
+		var commits []git.Commit
+		for _, mc := range r.commits {
+			if mc.Hash != c.Hash && g.ignoreReason(mc) == "" {
+				commits = append(commits, mc)
+			}
+		}
+		if len(commits) == 0 {
+			continue
+		}
 
-		version, err := g.incrementVersion(latest, commitsByModule[mod])
+		// commits that do not increment the version, such as docs,
+		// leave nothing to release
+		version, err := mg.incrementVersion(latest, commits)
 		if err != nil {
 			return nil, fmt.Errorf("could not increment version: %w", err)
 		}
+		if version == mg.strategy().Format(latest) {
+			logger.Info("module changes do not increment the version")
+			continue
+		}
 
-		versions[i] = prefix + version
+		logger.Info("module has unreleased changes", "commit", commits[0].Hash)
+		changed = append(changed, mod)
 	}
 
-	return versions, nil
+	return changed, nil
+}
+
+// forModule returns a Gotagger whose Config includes the overrides for m,
+// or g if there are no overrides for m.
+//
+// Overrides are found by module name, and then by module path.
+func (g *Gotagger) forModule(m module) *Gotagger {
+	mcfg, ok := g.Config.Modules[m.name]
+	if !ok {
+		mcfg, ok = g.Config.Modules[filepath.ToSlash(m.path)]
+	}
+	if !ok {
+		return g
+	}
+
+	g.logger.Info("applying module configuration", "module", m.name)
+	return g.withOverrides(mcfg)
+}
+
+// withOverrides returns a copy of g whose Config includes the overrides in mcfg.
+func (g *Gotagger) withOverrides(mcfg ModuleConfig) *Gotagger {
+	mg := *g
+	if mcfg.CommitTypeTable != nil {
+		mg.Config.CommitTypeTable = *mcfg.CommitTypeTable
+	}
+	if mcfg.PreMajor != nil {
+		mg.Config.PreMajor = *mcfg.PreMajor
+	}
+	if mcfg.VersionPrefix != nil {
+		mg.Config.VersionPrefix = *mcfg.VersionPrefix
+	}
+	if mcfg.DirtyWorktreeIncrement != nil {
+		mg.Config.DirtyWorktreeIncrement = *mcfg.DirtyWorktreeIncrement
+	}
+
+	return &mg
 }
 
-func (g *Gotagger) versionsSimple() ([]string, error) {
+func (g *Gotagger) versionsSimple() ([]release, error) {
 	// simple version calculation where we consider all tags that match the
 	// configured prefix
 
-	// need to ensure we default to the root path, "."
-	if len(g.Config.Paths) == 0 {
-		g.Config.Paths = []string{"."}
-	}
+	paths := g.paths()
 
-	var versions []string
-	for _, pth := range g.Config.Paths {
-		version, err := g.versionPath(pth)
+	var releases []release
+	for _, pth := range paths {
+		r, err := g.versionPath(pth, paths)
 		if err != nil {
 			return nil, err
 		}
 
-		versions = append(versions, version)
+		releases = append(releases, r)
 	}
 
-	return versions, nil
+	return releases, nil
 }
 
-func (g *Gotagger) versionPath(p string) (string, error) {
+// paths returns the configured paths, defaulting to the root path.
+func (g *Gotagger) paths() []string {
+	if len(g.Config.Paths) == 0 {
+		return []string{rootModulePath}
+	}
+
+	return g.Config.Paths
+}
+
+func (g *Gotagger) versionPath(p string, paths []string) (release, error) {
 	prefix := g.Config.VersionPrefix
 
-	tags, err := g.repo.Tags(head, prefix)
+	tags, err := g.tags(g.rev(), prefix)
 	if err != nil {
-		return "", err
-	}
-
-	// if the tag prefix is an empty string, then we need to filter out
-	// any tags that *have* a prefix
-	if prefix == "" {
-		filtered := make([]string, 0, len(tags))
-		for _, tag := range tags {
-			if unicode.IsDigit(rune(tag[0])) {
-				filtered = append(filtered, tag)
-			}
-		}
-		tags = filtered
+		return release{}, err
 	}
 
 	// find the latest tag and its hash
 	latest, hash, err := g.latest(tags, prefix)
 	if err != nil {
-		return "", err
+		return release{}, err
 	}
 
-	// find all commits between HEAD and the latest tag that touch files under
-	// directory p
-	commits, err := g.repo.RevList(head, hash, p)
+	// find all commits between the revision and the latest tag that touch
+	// files under directory p
+	commits, err := g.revList(g.rev(), hash, p)
 	if err != nil {
-		return "", fmt.Errorf("could not fetch commits HEAD..%s: %w", hash, err)
+		return release{}, fmt.Errorf("could not fetch commits %s..%s: %w", g.rev(), hash, err)
 	}
 
 	// group the commits by the configured paths
 	// this eliminates commits that only touched files that are
 	// beneath subpaths of p
-	commitsByPath := g.groupCommitsByPath(commits)
+	commitsByPath := g.groupCommitsByPath(commits, paths)
 
 	// increment the version
 	version, err := g.incrementVersion(latest, commitsByPath[p])
 	if err != nil {
-		return "", fmt.Errorf("could not increment version: %w", err)
+		return release{}, fmt.Errorf("could not increment version: %w", err)
 	}
 
-	return prefix + version, nil
+	return release{
+		path:     p,
+		prefix:   prefix,
+		version:  version,
+		previous: g.previousVersion(latest, hash),
+		commits:  commitsByPath[p],
+	}, nil
 }
 
 type module struct {
@@ -620,6 +1203,37 @@ type module struct {
 	prefix string
 }
 
+// goModFile is a go.mod file and its contents.
+type goModFile struct {
+	path string
+	data []byte
+}
+
+// release is the version calculated for a module, component, or path.
+type release struct {
+	module    module
+	component string
+	path      string
+	prefix    string
+	version   string
+	previous  string
+	commits   []git.Commit
+
+	// changed is whether commits since the previous version changed the version
+	changed bool
+}
+
+// tagVersion is a tag and the version parsed from it.
+type tagVersion struct {
+	tag     string
+	version *semver.Version
+}
+
+// tag returns the name of the tag for r.
+func (r release) tag() string {
+	return r.prefix + r.version
+}
+
 type sortByPath []module
 
 func (s sortByPath) Len() int      { return len(s) }
@@ -634,24 +1248,50 @@ func (s sortByPath) Less(i, j int) bool {
 }
 
 // extractCommitModules returns the modules referenced in the commit Footer(s).
-// If there are no modules referenced, then this returns the root module.
-func extractCommitModules(c git.Commit, modules []module) ([]module, error) {
-	// map module name to module for faster lookup
-	moduleNameMap := map[string]module{}
-	for _, m := range modules {
-		moduleNameMap[m.name] = m
-	}
-
+//
+// Each Modules footer is a comma or whitespace separated list of module names,
+// module paths relative to the root of the repository, or glob patterns
+// that match either. In release commits, the entries "*" and "changed" reference
+// every module with unreleased changes, and other commits ignore them. Modules are returned in the order they are first referenced.
+//
+// If the footers reference the modules with unreleased changes,
+// then they are also returned, so they are only found once.
+func (g *Gotagger) extractCommitModules(c git.Commit, modules []module) (commitModules, changed []module, err error) {
 	// extract modules from Modules footers
-	var commitModules []module
+	seen := map[module]bool{}
 	for _, footer := range c.Footers {
-		if footer.Title == "Modules" {
-			for _, moduleName := range strings.Split(footer.Text, ",") {
-				moduleName = strings.TrimSpace(moduleName)
-				if m, ok := moduleNameMap[moduleName]; ok {
+		if footer.Title != modulesFooter {
+			continue
+		}
+
+		for _, entry := range splitModulesFooter(footer.Text) {
+			var matched []module
+			switch entry {
+			case allChangedModules, changedModulesKeyword:
+				// only release commits release the changed modules
+				if !mapper.IsRelease(c.Type) {
+					continue
+				}
+
+				// only look for changed modules once
+				if changed == nil {
+					changed, err = g.changedModules(c, modules)
+					if err == nil && len(changed) == 0 {
+						err = errors.New("no modules have unreleased changes")
+					}
+				}
+				matched = changed
+			default:
+				matched, err = matchModules(entry, modules)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, m := range matched {
+				if !seen[m] {
+					seen[m] = true
 					commitModules = append(commitModules, m)
-				} else {
-					return nil, fmt.Errorf("no module %s found", moduleName)
 				}
 			}
 		}
@@ -670,9 +1310,75 @@ func extractCommitModules(c git.Commit, modules []module) ([]module, error) {
 		commitModules = []module{rootModule}
 	}
 
-	return commitModules, nil
+	return commitModules, changed, nil
+}
+
+// splitModulesFooter splits the text of a Modules footer into its entries.
+func splitModulesFooter(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
 }
 
+// matchModules returns the modules referenced by entry.
+//
+// An entry is matched against module names first, and then module paths.
+// A glob pattern returns every module whose name or path it matches.
+func matchModules(entry string, modules []module) ([]module, error) {
+	if strings.ContainsAny(entry, "*?[") {
+		pattern := cleanModulePath(entry)
+
+		var matched []module
+		for _, m := range modules {
+			nameMatch, err := path.Match(entry, m.name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid module pattern %s: %w", entry, err)
+			}
+
+			pathMatch, err := path.Match(pattern, filepath.ToSlash(m.path))
+			if err != nil {
+				return nil, fmt.Errorf("invalid module pattern %s: %w", entry, err)
+			}
+
+			if nameMatch || pathMatch {
+				matched = append(matched, m)
+			}
+		}
+
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("no module matches %s", entry)
+		}
+
+		return matched, nil
+	}
+
+	for _, m := range modules {
+		if m.name == entry {
+			return []module{m}, nil
+		}
+	}
+
+	modPath := cleanModulePath(entry)
+	for _, m := range modules {
+		if filepath.ToSlash(m.path) == modPath {
+			return []module{m}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no module %s found", entry)
+}
+
+// cleanModulePath returns p as a clean, slash-separated path
+// relative to the root of the repository.
+func cleanModulePath(p string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(p)), "/")
+}
+
+// groupCommitsByModule groups commits by the modules whose files they changed.
+//
+// The changes of a merge commit are the changes from its first parent,
+// so in first-parent mode a merge is attributed to every module
+// that its merged branch changed.
 func (g *Gotagger) groupCommitsByModule(commits []git.Commit, modules []module) map[module][]git.Commit {
 	g.logger.Info("group commits by module")
 
@@ -709,27 +1415,44 @@ func (g *Gotagger) groupCommitsByModule(commits []git.Commit, modules []module)
 	return grouped
 }
 
-func (g *Gotagger) groupCommitsByPath(commits []git.Commit) map[string][]git.Commit {
+func (g *Gotagger) groupCommitsByPath(commits []git.Commit, paths []string) map[string][]git.Commit {
 	g.logger.Info("group commits by path")
 
 	// make a map of paths for faster lookup
 	pathsMap := map[string]string{}
-	for _, p := range g.Config.Paths {
+	for _, p := range paths {
 		pathsMap[p] = p
 	}
 
+	return g.groupCommits(commits, pathsMap)
+}
+
+// groupCommits groups commits by the values of pathMap
+// for the paths of the files each commit changed.
+func (g *Gotagger) groupCommits(commits []git.Commit, pathMap map[string]string) map[string][]git.Commit {
 	grouped := map[string][]git.Commit{}
 	for _, commit := range commits {
 		logger := g.logger.WithValues("commit", commit.Hash)
+		mappedPaths := map[string]struct{}{}
 		for _, change := range commit.Changes {
-			if p, ok := isPathFile(change.SourceName, pathsMap); ok {
+			if p, ok := isPathFile(change.SourceName, pathMap); ok {
 				logger.Info("path affected by commit", "path", change.SourceName, "selectedPath", p)
-				grouped[p] = append(grouped[p], commit)
+				if _, mapped := mappedPaths[p]; !mapped {
+					grouped[p] = append(grouped[p], commit)
+					mappedPaths[p] = struct{}{}
+				}
 			}
 
-			if p, ok := isPathFile(change.DestName, pathsMap); ok {
+			if change.DestName == "" {
+				continue
+			}
+
+			if p, ok := isPathFile(change.DestName, pathMap); ok {
 				logger.Info("path affected by commit", "path", change.DestName, "selectedPath", p)
-				grouped[p] = append(grouped[p], commit)
+				if _, mapped := mappedPaths[p]; !mapped {
+					grouped[p] = append(grouped[p], commit)
+					mappedPaths[p] = struct{}{}
+				}
 			}
 		}
 	}
@@ -737,6 +1460,73 @@ func (g *Gotagger) groupCommitsByPath(commits []git.Commit) map[string][]git.Com
 	return grouped
 }
 
+// pathTagVersions returns the versions parsed from tags that have prefix.
+func (g *Gotagger) pathTagVersions(tags []string, prefix string) []tagVersion {
+	strategy := g.strategy()
+
+	var candidates []tagVersion
+	for _, tag := range tags {
+		// if the tag prefix is an empty string, then we need to filter out
+		// any tags that *have* a prefix
+		if prefix == "" && !unicode.IsDigit(rune(tag[0])) {
+			continue
+		}
+
+		if tver, err := strategy.Parse(strings.TrimPrefix(tag, prefix)); err == nil {
+			candidates = append(candidates, tagVersion{tag, tver})
+		}
+	}
+
+	return candidates
+}
+
+// previousVersion returns the version of latest,
+// or an empty string if latest is not a tagged version.
+func (g *Gotagger) previousVersion(latest *semver.Version, hash string) string {
+	if hash == "" {
+		return ""
+	}
+
+	return g.strategy().Format(latest)
+}
+
+// outputVersions returns the versions of releases in the configured output format.
+//
+// Semantic versions are the tag names of releases,
+// other formats do not include the tag prefix.
+func (g *Gotagger) outputVersions(releases []release) ([]string, error) {
+	format := g.Config.outputFormat()
+	if format == FormatSemVer {
+		return tagNames(releases), nil
+	}
+
+	versions := make([]string, len(releases))
+	for i, r := range releases {
+		v, err := RenderVersion(r.version, format)
+		if err != nil {
+			return nil, fmt.Errorf("could not render version %s: %w", r.tag(), err)
+		}
+		versions[i] = v
+	}
+
+	return versions, nil
+}
+
+// tagNames returns the tag names of releases.
+func tagNames(releases []release) []string {
+	tags := make([]string, len(releases))
+	for i, r := range releases {
+		tags[i] = r.tag()
+	}
+
+	return tags
+}
+
+// isIgnoredDir returns true if gotagger ignores directories named dirname.
+func isIgnoredDir(dirname string) bool {
+	return strings.HasPrefix(dirname, ".") || strings.HasPrefix(dirname, "_") || dirname == "testdata"
+}
+
 func isModuleFile(filename string, moduleMap map[string]module) (mod module, ok bool) {
 	for dir := filepath.Dir(filename); ; dir = filepath.Dir(dir) {
 		mod, ok = moduleMap[dir]
@@ -789,6 +1579,26 @@ func normalizePath(p string) string {
 }
 
 func validateCommitModules(commitModules, changedModules []module) (err error) {
+	extra, missing := diffModules(commitModules, changedModules)
+
+	var msg string
+	if len(extra) > 0 {
+		msg += "\nmodules not changed by commit: " + strings.Join(extra, ", ")
+	}
+	if len(missing) > 0 {
+		msg += "\nchanged modules not released by commit: " + strings.Join(missing, ", ")
+	}
+
+	if msg != "" {
+		err = errors.New("module validation failed:" + msg)
+	}
+
+	return
+}
+
+// diffModules returns the sorted names of the commitModules that are not changedModules,
+// and of the changedModules that are not commitModules.
+func diffModules(commitModules, changedModules []module) (extra, missing []string) {
 	// create a set of commit modules
 	commitMap := make(map[string]struct{})
 	for _, m := range commitModules {
@@ -801,7 +1611,6 @@ func validateCommitModules(commitModules, changedModules []module) (err error) {
 		changedMap[m.name] = struct{}{}
 	}
 
-	var extra []string
 	for modName := range commitMap {
 		if _, ok := changedMap[modName]; !ok {
 			// this is extra
@@ -810,7 +1619,6 @@ func validateCommitModules(commitModules, changedModules []module) (err error) {
 	}
 	sort.StringSlice(extra).Sort()
 
-	var missing []string
 	for modName := range changedMap {
 		if _, ok := commitMap[modName]; !ok {
 			// this is missing
@@ -819,17 +1627,5 @@ func validateCommitModules(commitModules, changedModules []module) (err error) {
 	}
 	sort.StringSlice(missing).Sort()
 
-	var msg string
-	if len(extra) > 0 {
-		msg += "\nmodules not changed by commit: " + strings.Join(extra, ", ")
-	}
-	if len(missing) > 0 {
-		msg += "\nchanged modules not released by commit: " + strings.Join(missing, ", ")
-	}
-
-	if msg != "" {
-		err = errors.New("module validation failed:" + msg)
-	}
-
 	return
 }
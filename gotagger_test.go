@@ -4,8 +4,11 @@
 package gotagger
 
 import (
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,7 +17,8 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-logr/logr"
-	"github.com/sassoftware/gotagger/internal/commit"
+	"github.com/go-logr/logr/funcr"
+	"github.com/sassoftware/gotagger/commit"
 	"github.com/sassoftware/gotagger/internal/git"
 	"github.com/sassoftware/gotagger/internal/testutils"
 	"github.com/sassoftware/gotagger/mapper"
@@ -96,7 +100,7 @@ func TestGotagger_latestModule(t *testing.T) {
 
 			tt.repoFunc(t, repo, path)
 
-			tags, err := g.repo.Tags("HEAD", tt.module.prefix+"v")
+			tags, err := g.tags("HEAD", tt.module.prefix+"v")
 			require.NoError(t, err)
 
 			if got, _, err := g.latestModule(tags, tt.module); assert.NoError(t, err) {
@@ -146,6 +150,229 @@ func TestGotagger_ModuleVersions_PreMajor(t *testing.T) {
 	}
 }
 
+func TestGotagger_ModuleVersions_overrides(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	simpleGoRepo(t, repo, path)
+
+	// without overrides
+	if v, err := g.ModuleVersions(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0", "sub/module/v0.1.1"}, v)
+	}
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{
+	"modules": {
+		"sub/module": {"incrementMappings": {"fix": "minor"}, "versionPrefix": "release-"}
+	}
+}`)))
+
+	// mappings override by name, prefix override by path
+	if v, err := g.ModuleVersions(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0", "sub/module/release-0.1.0"}, v)
+	}
+
+	// tags with the overridden prefix are found
+	testutils.CreateTag(t, repo, "sub/module/release-0.2.0")
+	testutils.CommitFile(t, repo, path, filepath.Join("sub", "module", "file"), "fix: another fix", []byte(`contents`))
+	if v, err := g.ModuleVersions("foo/sub/module"); assert.NoError(t, err) {
+		assert.Equal(t, []string{"sub/module/release-0.3.0"}, v)
+	}
+}
+
+func TestGotagger_Version_module_dirty_override(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	require.NoError(t, os.WriteFile(filepath.Join(path, "untracked"), []byte("untracked\n"), 0600))
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{
+	"incrementDirtyWorktree": "patch",
+	"modules": {".": {"incrementDirtyWorktree": "minor"}}
+}`)))
+
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", v)
+	}
+}
+
+func TestGotagger_components(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "README.md", "docs: add readme", []byte("readme\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("web", "index.js"), "feat: add frontend", []byte("index\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("web", "assets", "logo.svg"), "feat: add logo", []byte("logo\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("services", "api", "main.py"), "feat: add api", []byte("main\n"))
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{
+	"components": [
+		{"name": "frontend", "paths": ["web"]},
+		{"name": "api", "paths": ["services/api"], "tagPrefix": "api/v", "incrementMappings": {"feat": "minor", "fix": "minor"}},
+		{"name": "assets", "paths": ["web/assets"], "versionPrefix": ""}
+	]
+}`)))
+
+	if v, err := g.ModuleVersions(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"frontend/v0.1.0", "api/v0.1.0", "assets/0.1.0"}, v)
+	}
+
+	testutils.CreateTag(t, repo, "frontend/v0.1.0")
+	testutils.CreateTag(t, repo, "api/v0.1.0")
+	testutils.CreateTag(t, repo, "assets/0.1.0")
+
+	// files belong to the component with the most specific path
+	testutils.CommitFile(t, repo, path, filepath.Join("services", "api", "main.py"), "fix: fix api", []byte("fixed\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("web", "assets", "logo.svg"), "fix: fix logo", []byte("fixed\n"))
+	testutils.CommitFile(t, repo, path, "README.md", "feat: unrelated change", []byte("more readme\n"))
+
+	// only changed components are tagged
+	g.Config.CreateTag = true
+	g.Config.Force = true
+	if v, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"api/v0.2.0", "assets/0.1.1"}, v)
+	}
+	for _, tag := range []string{"api/v0.2.0", "assets/0.1.1"} {
+		_, err := repo.Tag(tag)
+		assert.NoError(t, err, tag)
+	}
+
+	type summary struct {
+		component, tag string
+		commits        int
+	}
+
+	releases, err := g.History()
+	require.NoError(t, err)
+
+	var got []summary
+	for _, r := range releases {
+		got = append(got, summary{r.Component, r.Tag, r.Commits})
+	}
+	assert.Equal(t, []summary{
+		{"frontend", "frontend/v0.1.0", 1},
+		{"api", "api/v0.1.0", 1},
+		{"api", "api/v0.2.0", 1},
+		{"assets", "assets/0.1.0", 1},
+		{"assets", "assets/0.1.1", 1},
+	}, got)
+
+	g.Config.Paths = []string{"web"}
+	_, err = g.ModuleVersions()
+	assert.EqualError(t, err, "cannot use path filtering with components")
+
+	g.Config.Paths = nil
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+	_, err = g.ModuleVersions()
+	assert.EqualError(t, err, "cannot use components with go modules")
+}
+
+func TestGotagger_components_unreleased(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, filepath.Join("web", "index.js"), "feat: add frontend", []byte("index\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("docs", "index.md"), "docs: add docs", []byte("docs\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("services", "api", "main.py"), "chore: add api", []byte("main\n"))
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{
+	"defaultIncrement": "none",
+	"components": [
+		{"name": "frontend", "paths": ["web"]},
+		{"name": "api", "paths": ["services/api"]},
+		{"name": "cli", "paths": ["cli"]}
+	]
+}`)))
+
+	// components that were never released are only tagged if they changed
+	g.Config.CreateTag = true
+	g.Config.Force = true
+	if v, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"frontend/v0.1.0"}, v)
+	}
+	assert.Equal(t, "frontend/v0.1.0\n", testutils.Git(t, path, "tag", "--list"))
+}
+
+func TestGotagger_calver(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: add foo", []byte("foo\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CreateTag(t, repo, "2026.09.4")
+	testutils.CommitFile(t, repo, path, "foo", "fix: fix foo", []byte("fixed\n"))
+
+	g.Config.VersionPrefix = ""
+	g.Config.VersionStrategy = &CalVer{
+		Layout: DefaultCalVerLayout,
+		Now:    func() time.Time { return time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC) },
+	}
+
+	// semver tags are ignored
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "2026.10.0", v)
+	}
+
+	// release commits are tagged
+	g.Config.CreateTag = true
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: foo", []byte("changes\n"))
+	if v, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"2026.10.0"}, v)
+	}
+	_, err := repo.Tag("2026.10.0")
+	assert.NoError(t, err)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: more foo", []byte("more\n"))
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "2026.10.1", v)
+	}
+
+	// go modules require semver
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+	_, err = g.Version()
+	assert.EqualError(t, err, "cannot use the calver version strategy with go modules")
+}
+
+func TestGotagger_calver_untagged(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "README.md", "docs: add readme", []byte("readme\n"))
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{"versionStrategy": "calver", "versionPrefix": "", "defaultIncrement": "none"}`)))
+	g.Config.VersionStrategy = &CalVer{
+		Layout: DefaultCalVerLayout,
+		Now:    func() time.Time { return time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC) },
+	}
+
+	// without a previous release the version is the current period
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "2026.10.0", v)
+	}
+}
+
+func TestGotagger_OutputFormat(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "setup.py", "feat: add setup.py", []byte("setup\n"))
+	testutils.CreateTag(t, repo, "v1.2.0-rc.1")
+
+	g.Config.OutputFormat = FormatPEP440
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "1.2.0rc1", v)
+	}
+
+	// tags are still semantic versions
+	g.Config.CreateTag = true
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: 1.2.0", []byte("changes\n"))
+	if v, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"1.2.0"}, v)
+	}
+	_, err := repo.Tag("v1.2.0")
+	assert.NoError(t, err)
+
+	g.Config.OutputFormat = FormatDebian
+	if v, err := g.ModuleVersions(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"1.2.0"}, v)
+	}
+}
+
 func TestGotagger_versioning(t *testing.T) {
 	tests := []struct {
 		disabled bool
@@ -305,6 +532,91 @@ func TestGotagger_versioning(t *testing.T) {
 				"Version": checkVersion("v1.1.0"),
 			},
 		},
+		{
+			title:  "release changed v1 on master",
+			prefix: "v",
+			repoFunc: func(t testutils.T, r *sgit.Repository, p string) {
+				masterV1GitRepo(t, r, p)
+
+				testutils.CommitFile(t, r, p, filepath.Join("bar", "bar.go"), "feat: add bar/bar.go", []byte("bar\n"))
+			},
+			message: "release: the changed things\n\nModules: *",
+			files: []testutils.FileCommit{
+				{
+					Path:     filepath.Join("bar", "CHANGELOG.md"),
+					Contents: []byte("# Bar Change Log\n"),
+				},
+			},
+			checks: map[string]gotaggerCheckFunc{
+				"TagRepo": checkTagRepo([]string{"bar/v1.1.0"}),
+				"Version": checkVersion("v1.0.0"),
+			},
+		},
+		{
+			title:  "release all changed v1 on master",
+			prefix: "v",
+			repoFunc: func(t testutils.T, r *sgit.Repository, p string) {
+				masterV1GitRepo(t, r, p)
+
+				testutils.CommitFile(t, r, p, "foo.go", "feat: add foo.go", []byte("foo\n"))
+				testutils.CommitFile(t, r, p, filepath.Join("bar", "bar.go"), "feat: add bar/bar.go", []byte("bar\n"))
+			},
+			message: "release: all the changed things\n\nModules: changed",
+			files: []testutils.FileCommit{
+				{
+					Path:     "CHANGELOG.md",
+					Contents: []byte("# Foo Change Log\n"),
+				},
+				{
+					Path:     filepath.Join("bar", "CHANGELOG.md"),
+					Contents: []byte("# Bar Change Log\n"),
+				},
+			},
+			checks: map[string]gotaggerCheckFunc{
+				"TagRepo": checkTagRepo([]string{"v1.1.0", "bar/v1.1.0"}),
+				"Version": checkVersion("v1.1.0"),
+			},
+		},
+		{
+			title:  "release all changed with one file v1 on master",
+			prefix: "v",
+			repoFunc: func(t testutils.T, r *sgit.Repository, p string) {
+				masterV1GitRepo(t, r, p)
+
+				testutils.CommitFile(t, r, p, "foo.go", "feat: add foo.go", []byte("foo\n"))
+				testutils.CommitFile(t, r, p, filepath.Join("bar", "bar.go"), "feat: add bar/bar.go", []byte("bar\n"))
+			},
+			message: "release: all the changed things\n\nModules: *",
+			files: []testutils.FileCommit{
+				{
+					Path:     "CHANGELOG.md",
+					Contents: []byte("# Change Log\n"),
+				},
+			},
+			checks: map[string]gotaggerCheckFunc{
+				"TagRepo": checkTagRepo([]string{"v1.1.0", "bar/v1.1.0"}),
+				"Version": checkVersion("v1.1.0"),
+			},
+		},
+		{
+			title:  "release changed with another module's file v1 on master",
+			prefix: "v",
+			repoFunc: func(t testutils.T, r *sgit.Repository, p string) {
+				masterV1GitRepo(t, r, p)
+
+				testutils.CommitFile(t, r, p, filepath.Join("bar", "bar.go"), "feat: add bar/bar.go", []byte("bar\n"))
+			},
+			message: "release: the changed things\n\nModules: changed",
+			files: []testutils.FileCommit{
+				{
+					Path:     "CHANGELOG.md",
+					Contents: []byte("# Change Log\n"),
+				},
+			},
+			checks: map[string]gotaggerCheckFunc{
+				"TagRepo": checkTagRepo([]string{"bar/v1.1.0"}),
+			},
+		},
 		{
 			title:  "release root v2 on master implicit",
 			prefix: "v",
@@ -1053,6 +1365,50 @@ Modules: foo/bar, foo
 	assert.EqualError(t, err, "module validation failed:\nmodules not changed by commit: foo/bar")
 }
 
+func TestGotagger_TagRepo_no_changed_modules(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	masterV1GitRepo(t, repo, path)
+
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: nothing\n\nModules: *", []byte(`changes`))
+
+	g.Config.CreateTag = true
+	_, err := g.TagRepo()
+	assert.EqualError(t, err, "no modules have unreleased changes")
+}
+
+func TestGotagger_TagRepo_changed_modules_without_increment(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	masterV1GitRepo(t, repo, path)
+
+	testutils.CommitFile(t, repo, path, "foo.go", "feat: add foo.go", []byte("foo\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "README.md"), "chore: add a bar readme", []byte("bar\n"))
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the changed modules\n\nModules: *", []byte("changes\n"))
+
+	// bar only has chores, so its version does not change and it is not released
+	g.Config.CommitTypeTable = mapper.NewTable(mapper.Mapper{mapper.TypeFeature: mapper.IncrementMinor, mapper.TypeChore: mapper.IncrementNone}, mapper.IncrementPatch)
+	g.Config.CreateTag = true
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+	}
+	assert.Empty(t, testutils.Git(t, path, "tag", "--list", "bar/v1.1.0", "bar/v1.0.1"))
+}
+
+func TestGotagger_TagRepo_changed_modules_not_release(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	masterV1GitRepo(t, repo, path)
+
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "bar.go"), "feat: add bar/bar.go\n\nModules: *", []byte("bar\n"))
+
+	// only release commits release the changed modules
+	g.Config.CreateTag = true
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.0.0"}, versions)
+	}
+}
+
 func TestGotagger_TagRepo_validation_missing(t *testing.T) {
 	g, repo, path := newGotagger(t)
 
@@ -1094,60 +1450,361 @@ func TestGotagger_TagRepo_validation_missing(t *testing.T) {
 	assert.EqualError(t, err, "module validation failed:\nchanged modules not released by commit: foo/bar")
 }
 
-func TestGotagger_Version(t *testing.T) {
-	g, repo, path := newGotagger(t)
+func TestGotagger_TagRepo_validation_unreleased(t *testing.T) {
+	tests := []struct {
+		title       string
+		changes     []string
+		message     string
+		want        []string
+		wantErr     string
+		wantWarning string
+	}{
+		{
+			title:   "release commit changes other modules",
+			changes: []string{filepath.Join("bar", "bar.go")},
+			message: "release: the bars\n\nModules: foo/bar",
+			want:    []string{"bar/v1.1.0"},
+		},
+		{
+			title:   "release all changed",
+			changes: []string{"foo.go", filepath.Join("bar", "bar.go")},
+			message: "release: all the things\n\nModules: *",
+			want:    []string{"v1.1.0", "bar/v1.1.0"},
+		},
+		{
+			title:   "module without changes",
+			changes: []string{filepath.Join("bar", "bar.go")},
+			message: "release: all the things\n\nModules: foo/bar, foo",
+			wantErr: "module validation failed:\nmodules without unreleased changes: foo",
+		},
+		{
+			title:       "changed module not released",
+			changes:     []string{"foo.go", filepath.Join("bar", "bar.go")},
+			message:     "release: the bars\n\nModules: foo/bar",
+			want:        []string{"bar/v1.1.0"},
+			wantWarning: "warning: changed modules not released by commit: foo\n",
+		},
+	}
 
-	simpleGoRepo(t, repo, path)
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
 
-	if v, err := g.Version(); assert.NoError(t, err) {
-		assert.Equal(t, "v1.1.0", v)
-	}
-}
+			g, repo, path := newGotagger(t)
 
-func TestGotagger_Version_no_module(t *testing.T) {
-	g, repo, path := newGotagger(t)
+			masterV1GitRepo(t, repo, path)
+			for _, change := range tt.changes {
+				testutils.CommitFile(t, repo, path, change, "feat: add "+filepath.ToSlash(change), []byte("changes\n"))
+			}
 
-	testutils.SimpleGitRepo(t, repo, path)
+			// the release commit only changes the root module
+			testutils.CommitFile(t, repo, path, "CHANGELOG.md", tt.message, []byte("changes\n"))
 
-	if v, err := g.Version(); assert.NoError(t, err) {
-		assert.Equal(t, "v1.1.0", v)
+			var warnings strings.Builder
+			g.SetWarningOutput(&warnings)
+			g.Config.CreateTag = true
+			g.Config.ModuleValidation = ValidateUnreleased
+
+			got, err := g.TagRepo()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantWarning, warnings.String())
+		})
 	}
 }
 
-func TestGotagger_Version_path_filter(t *testing.T) {
+func TestGotagger_TagRepo_existing_tag(t *testing.T) {
 	g, repo, path := newGotagger(t)
 
-	g.Config.Paths = []string{"baz"}
-	g.Config.VersionPrefix = "baz/v"
-
-	testutils.SimpleGitRepo(t, repo, path)
+	simpleGoRepo(t, repo, path)
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
 
-	// need to be on the "other" branch
-	w, err := repo.Worktree()
-	if err != nil {
-		t.Fatal(err)
+	g.Config.CreateTag = true
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
 	}
 
-	if err := w.Checkout(&sgit.CheckoutOptions{
-		Branch: plumbing.NewBranchReferenceName("other"),
-	}); err != nil {
-		t.Fatal(err)
+	// tagging again is a no-op
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
 	}
+}
 
-	if v, err := g.Version(); assert.NoError(t, err) {
-		assert.Equal(t, "baz/v0.1.0", v)
-	}
+func TestGotagger_TagRepo_conflicts(t *testing.T) {
+	// tagOther tags the head of the other branch with tags
+	tagOther := func(t testutils.T, repo *sgit.Repository, tags ...string) {
+		w, err := repo.Worktree()
+		require.NoError(t, err)
 
-	// make a change to baz/
-	testutils.CommitFile(t, repo, path, filepath.Join("baz", "baz.txt"), "fix: baz is broke\n", []byte("some change\n"))
-	if v, err := g.Version(); assert.NoError(t, err) {
-		assert.Equal(t, "baz/v0.1.0", v)
+		require.NoError(t, w.Checkout(&sgit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("other")}))
+		for _, tag := range tags {
+			testutils.CreateTag(t, repo, tag)
+		}
+		require.NoError(t, w.Checkout(&sgit.CheckoutOptions{Branch: plumbing.Master}))
 	}
 
-	// force version
-	testutils.CreateTag(t, repo, "baz/v1.0.0")
-	if v, err := g.Version(); assert.NoError(t, err) {
-		assert.Equal(t, "baz/v1.0.0", v)
+	tests := []struct {
+		title    string
+		pushTag  bool
+		repoFunc setupRepoFunc
+		want     string
+	}{
+		{
+			title: "local tag on other commit",
+			repoFunc: func(t testutils.T, repo *sgit.Repository, path string) {
+				tagOther(t, repo, "v1.1.0")
+			},
+			want: "conflicting tags found:\ntag v1.1.0 already exists on commit ",
+		},
+		{
+			title:   "remote tag on other commit",
+			pushTag: true,
+			repoFunc: func(t testutils.T, repo *sgit.Repository, path string) {
+				tagOther(t, repo, "v1.1.0")
+				testutils.PushTag(t, repo, "origin", "v1.1.0")
+				require.NoError(t, repo.DeleteTag("v1.1.0"))
+			},
+			want: "conflicting tags found:\ntag v1.1.0 already exists on remote origin on commit ",
+		},
+		{
+			title: "remote tag on other commit without pushing",
+			repoFunc: func(t testutils.T, repo *sgit.Repository, path string) {
+				tagOther(t, repo, "v1.1.0")
+				testutils.PushTag(t, repo, "origin", "v1.1.0")
+				require.NoError(t, repo.DeleteTag("v1.1.0"))
+			},
+			want: "conflicting tags found:\ntag v1.1.0 already exists on remote origin on commit ",
+		},
+		{
+			title:   "newer remote tag",
+			pushTag: true,
+			repoFunc: func(t testutils.T, repo *sgit.Repository, path string) {
+				tagOther(t, repo, "v1.2.0")
+				testutils.PushTag(t, repo, "origin", "v1.2.0")
+				require.NoError(t, repo.DeleteTag("v1.2.0"))
+			},
+			want: "conflicting tags found:\nremote origin has newer version v1.2.0 than v1.1.0: fetch tags and try again",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			g, repo, path := newGotagger(t)
+
+			testutils.SimpleGitRepo(t, repo, path)
+			testutils.AddRemote(t, repo, "origin")
+			tt.repoFunc(t, repo, path)
+
+			testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
+			tags := testutils.Git(t, path, "tag", "--list")
+
+			g.Config.CreateTag = true
+			g.Config.PushTag = tt.pushTag
+			_, err := g.TagRepo()
+			if assert.ErrorIs(t, err, ErrTagConflict) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+
+			// no tags should be left behind
+			assert.Equal(t, tags, testutils.Git(t, path, "tag", "--list"))
+			assert.Empty(t, testutils.Git(t, path, "tag", "--points-at", "HEAD"))
+		})
+	}
+}
+
+func TestGotagger_TagRepo_unreachable_remote(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.Git(t, path, "remote", "add", "origin", filepath.Join(t.TempDir(), "missing"))
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
+
+	// tags can be created without the remote, but not pushed
+	g.Config.CreateTag = true
+	g.Config.PushTag = true
+	_, err := g.TagRepo()
+	assert.Error(t, err)
+	assert.Empty(t, testutils.Git(t, path, "tag", "--points-at", "HEAD"))
+
+	var warnings strings.Builder
+	g.SetWarningOutput(&warnings)
+	g.Config.PushTag = false
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+	}
+	assert.Contains(t, warnings.String(), "warning: could not check the tags of remote origin:")
+	assert.Equal(t, "v1.1.0\n", testutils.Git(t, path, "tag", "--points-at", "HEAD"))
+}
+
+func TestGotagger_TagRepo_push(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	remote, _ := testutils.AddRemote(t, repo, "origin")
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
+
+	g.Config.CreateTag = true
+	g.Config.PushTag = true
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+		_, err := remote.Tag("v1.1.0")
+		assert.NoError(t, err)
+	}
+
+	// pushing again is a no-op
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+	}
+}
+
+func TestGotagger_TagRepo_tag_message(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	masterV1GitRepo(t, repo, path)
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "bar.go"), "feat: add bar/bar.go", []byte("bar\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "CHANGELOG.md"), "release: the bars\n\nModules: foo/bar", []byte("# Bar Change Log\n"))
+
+	g.Config.CreateTag = true
+	g.Config.TagMessage = `{{.Module}} {{.Version}}
+
+Changes since {{.PreviousTag}}:
+{{range .Commits}}- {{.Header}}
+{{end}}`
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"bar/v1.1.0"}, versions)
+
+		ref, err := repo.Tag("bar/v1.1.0")
+		require.NoError(t, err)
+
+		if tag, err := repo.TagObject(ref.Hash()); assert.NoError(t, err) {
+			assert.Equal(t, "foo/bar 1.1.0\n\nChanges since bar/v1.0.0:\n- release: the bars\n- feat: add bar/bar.go\n", tag.Message)
+		}
+	}
+}
+
+func TestGotagger_TagRepo_lightweight(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	simpleGoRepo(t, repo, path)
+	h := testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
+
+	g.Config.CreateTag = true
+	g.Config.LightweightTags = true
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+
+		ref, err := repo.Tag("v1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, h, ref.Hash())
+	}
+}
+
+func TestGotagger_TagRepo_signed(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not found")
+	}
+
+	g, repo, path := newGotagger(t)
+
+	simpleGoRepo(t, repo, path)
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
+
+	// generate an ssh signing key and trust it
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "key")
+	require.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).Run())
+
+	pubKey, err := os.ReadFile(key + ".pub")
+	require.NoError(t, err)
+
+	allowedSigners := filepath.Join(keyDir, "allowed_signers")
+	require.NoError(t, os.WriteFile(allowedSigners, append([]byte(testutils.GotaggerEmail+" "), pubKey...), 0600))
+
+	for _, args := range [][]string{
+		{"user.signingkey", key + ".pub"},
+		{"gpg.ssh.allowedSignersFile", allowedSigners},
+	} {
+		require.NoError(t, exec.Command("git", append([]string{"-C", path, "config"}, args...)...).Run())
+	}
+
+	g.Config.CreateTag = true
+	g.Config.SignTags = true
+	g.Config.SigningFormat = "ssh"
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+		assert.NoError(t, g.repo.VerifyTag("v1.1.0"))
+	}
+
+	// the signed tag is used as the base version
+	g.Config.IgnoreUnsignedTags = true
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", v)
+	}
+}
+
+func TestGotagger_Version(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	simpleGoRepo(t, repo, path)
+
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", v)
+	}
+}
+
+func TestGotagger_Version_no_module(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", v)
+	}
+}
+
+func TestGotagger_Version_path_filter(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	g.Config.Paths = []string{"baz"}
+	g.Config.VersionPrefix = "baz/v"
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	// need to be on the "other" branch
+	w, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Checkout(&sgit.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName("other"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "baz/v0.1.0", v)
+	}
+
+	// make a change to baz/
+	testutils.CommitFile(t, repo, path, filepath.Join("baz", "baz.txt"), "fix: baz is broke\n", []byte("some change\n"))
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "baz/v0.1.0", v)
+	}
+
+	// force version
+	testutils.CreateTag(t, repo, "baz/v1.0.0")
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "baz/v1.0.0", v)
 	}
 }
 
@@ -1165,6 +1822,70 @@ func TestGotagger_Version_tag_head(t *testing.T) {
 	}
 }
 
+func TestGotagger_Version_VerifyTags(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	// tags created by SimpleGitRepo are not signed
+	g.Config.VerifyTags = true
+	if _, err := g.Version(); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "tag v1.0.0 does not have a valid signature")
+	}
+
+	// ignoring unsigned tags falls back to the default base version
+	g.Config.IgnoreUnsignedTags = true
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v0.1.0", v)
+	}
+}
+
+func TestGotagger_Version_Rev(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add foo/v2 go.mod", []byte("module foo/v2\n"))
+
+	// dirty worktree is ignored for other revisions
+	g.Config.DirtyWorktreeIncrement = mapper.IncrementMinor
+	require.NoError(t, os.WriteFile(filepath.Join(path, "untracked"), []byte("untracked\n"), 0600))
+
+	tests := map[string]string{
+		"":       "v2.1.0",
+		"HEAD~1": "v1.1.0",
+		"v1.0.0": "v1.0.0",
+		"other":  "v0.1.0",
+	}
+
+	for rev, want := range tests {
+		g.Config.Rev = rev
+		if v, err := g.Version(); assert.NoError(t, err, rev) {
+			assert.Equal(t, want, v, rev)
+		}
+	}
+}
+
+func TestGotagger_TagRepo_Rev(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	simpleGoRepo(t, repo, path)
+	h := testutils.CommitFile(t, repo, path, "CHANGELOG.md", "release: the foos", []byte(`changes`))
+	testutils.CommitFile(t, repo, path, "foo.go", "feat: add foo.go", []byte(`foo`))
+
+	g.Config.CreateTag = true
+	g.Config.Rev = h.String()
+	if versions, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.1.0"}, versions)
+
+		ref, err := repo.Tag("v1.1.0")
+		require.NoError(t, err)
+
+		if tag, err := repo.TagObject(ref.Hash()); assert.NoError(t, err) {
+			assert.Equal(t, h, tag.Target)
+		}
+	}
+}
+
 func TestGotagger_Version_IgnoreModules(t *testing.T) {
 	g, repo, path := newGotagger(t)
 
@@ -1197,6 +1918,194 @@ func TestGotagger_Version_breaking(t *testing.T) {
 	}
 }
 
+func TestGotagger_Version_CommitParser(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.CreateTag(t, repo, "v1.1.0")
+	testutils.CommitFile(t, repo, path, "bug", ":bug: fix a bug", []byte("bug"))
+
+	// gitmoji commits are not conventional commits
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.1", got)
+	}
+
+	testutils.CommitFile(t, repo, path, "feature", "✨ add a feature", []byte("feature"))
+	g.Config.CommitParser = &commit.Gitmoji{}
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.2.0", got)
+	}
+
+	testutils.CommitFile(t, repo, path, "api", ":boom: remove the api", []byte("api"))
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v2.0.0", got)
+	}
+
+	// breaking changes in the body
+	p, err := commit.NewRegex(`(?s)^(?P<type>\w+): [^\n]*(?P<breaking>.*\nBREAKING: .*)?`)
+	require.NoError(t, err)
+	g.Config.CommitParser = p
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.1", got)
+	}
+
+	testutils.CommitFile(t, repo, path, "other", "fix: change the api\n\nBREAKING: the api changed", []byte("other"))
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v2.0.0", got)
+	}
+}
+
+func TestGotagger_Version_type_case(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: add foo", []byte("foo\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+
+	// types are case-insensitive
+	testutils.CommitFile(t, repo, path, "foo", "Feat: more foo", []byte("more\n"))
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", v)
+	}
+
+	// footers are only found in the last paragraph
+	testutils.CommitFile(t, repo, path, "foo", "feat: remove foo\n\nBREAKING CHANGE: removes foo\n\nSee the migration guide.", []byte("\n"))
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", v)
+	}
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: remove foo\n\nSee the migration guide.\n\nBREAKING CHANGE: removes foo", []byte("removed\n"))
+	if v, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v2.0.0", v)
+	}
+
+	// release commits are case-insensitive
+	testutils.CommitFile(t, repo, path, "CHANGELOG.md", "Release: foo", []byte("changes\n"))
+	g.Config.CreateTag = true
+	if v, err := g.TagRepo(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v2.0.0"}, v)
+	}
+	_, err := repo.Tag("v2.0.0")
+	assert.NoError(t, err)
+}
+
+func TestGotagger_Version_merges(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: foo", []byte("foo"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.Git(t, path, "checkout", "-q", "-b", "feature")
+	testutils.CommitFile(t, repo, path, "feature", "feat!: wip", []byte("wip"))
+	testutils.Git(t, path, "checkout", "-q", "-")
+	testutils.MergeBranch(t, path, "feature", "Merge pull request #1 from feature\n\nfix: add a feature")
+
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v2.0.0", got)
+	}
+
+	// only the title of the merged pull request counts
+	g.Config.FirstParent = true
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.0.1", got)
+	}
+
+	g.Config.FirstParent = false
+	g.Config.IgnoreMerges = true
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v2.0.0", got)
+	}
+}
+
+func TestGotagger_Version_merge_no_ff(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: foo", []byte("foo"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.Git(t, path, "checkout", "-q", "-b", "docs")
+	testutils.CommitFile(t, repo, path, "README.md", "docs: add readme", []byte("readme"))
+	testutils.Git(t, path, "checkout", "-q", "-")
+	testutils.Git(t, path, "merge", "-q", "--no-ff", "--no-edit", "docs")
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{"incrementMappings": {"docs": "none"}}`)))
+
+	// the merge commit does not change anything by itself
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.0.0", got)
+	}
+}
+
+func TestGotagger_FirstParent(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+	testutils.CommitFile(t, repo, path, "bar/go.mod", "feat: add bar", []byte("module foo/bar\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CreateTag(t, repo, "bar/v1.0.0")
+
+	// a release tagged on a feature branch
+	testutils.Git(t, path, "checkout", "-q", "-b", "feature")
+	testutils.CommitFile(t, repo, path, "bar/bar.go", "feat: wip", []byte("package bar\n"))
+	testutils.CreateTag(t, repo, "bar/v1.1.0")
+	testutils.CommitFile(t, repo, path, "bar/baz.go", "feat: more wip", []byte("package bar\n"))
+	testutils.Git(t, path, "checkout", "-q", "-")
+	testutils.MergeBranch(t, path, "feature", "Merge pull request #1 from feature\n\nfix: fix bar")
+
+	if got, err := g.ModuleVersions(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.0.0", "bar/v1.2.0"}, got)
+	}
+
+	// the merge commit changed bar, and the branch tag is not on the first-parent history
+	g.Config.FirstParent = true
+	if got, err := g.ModuleVersions(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v1.0.0", "bar/v1.0.1"}, got)
+	}
+}
+
+func TestGotagger_Version_ignored_commits(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: foo", []byte("foo"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CommitFile(t, repo, path, "foo", "feat!: reformat everything\n\nRelease-Ignore: true", []byte("reformatted foo"))
+
+	// the release-ignore footer excludes the commit
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.0.0", got)
+	}
+
+	// even if the header does not follow the convention
+	testutils.CommitFile(t, repo, path, "foo", "Bump foo from 1 to 2\n\nBumps foo.\n\nRelease-Ignore: true\nSigned-off-by: Bot <bot@example.com>", []byte("bumped foo"))
+	// ignored commits are logged for -debug
+	var logs []string
+	g.logger = funcr.New(func(prefix, args string) {
+		if strings.Contains(args, `"msg"="ignoring commit"`) {
+			logs = append(logs, args)
+		}
+	}, funcr.Options{})
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.0.0", got)
+	}
+	if assert.Len(t, logs, 2) {
+		assert.Contains(t, logs[0], `"reason"="Release-Ignore footer"`)
+	}
+	g.logger = logr.Discard()
+
+	testutils.CommitFile(t, repo, path, "bar", "feat: bump dependencies", []byte("bar"))
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", got)
+	}
+
+	// commits by ignored authors are excluded
+	g.Config.IgnoreAuthors = []string{"*.TEST@nowhere.com"}
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.0.0", got)
+	}
+
+	g.Config.IgnoreAuthors = []string{"bot@example.com"}
+	if got, err := g.Version(); assert.NoError(t, err) {
+		assert.Equal(t, "v1.1.0", got)
+	}
+}
+
 func TestNew(t *testing.T) {
 	_, path := testutils.NewGitRepo(t)
 
@@ -1537,7 +2446,7 @@ func Test_filterCommitsByModule(t *testing.T) {
 			modules, err := g.findAllModules(nil)
 			require.NoError(t, err)
 
-			commits, err := g.repo.RevList("HEAD", "")
+			commits, err := g.revList("HEAD", "")
 			require.NoError(t, err)
 
 			groupedCommits := g.groupCommitsByModule(commits, modules)
@@ -1554,6 +2463,102 @@ func Test_filterCommitsByModule(t *testing.T) {
 	}
 }
 
+func Test_extractCommitModules(t *testing.T) {
+	var (
+		foo     = module{".", "foo", ""}
+		bar     = module{"bar", "foo/bar", "bar/"}
+		pluginA = module{filepath.Join("plugins", "a"), "foo/plugins/a", "plugins/a/"}
+		pluginB = module{filepath.Join("plugins", "b"), "foo/plugins/b", "plugins/b/"}
+		modules = []module{foo, bar, pluginA, pluginB}
+	)
+
+	tests := []struct {
+		title   string
+		message string
+		want    []module
+		wantErr string
+	}{
+		{
+			title:   "no footer",
+			message: "release: the foos",
+			want:    []module{foo},
+		},
+		{
+			title:   "comma separated",
+			message: "release: the things\n\nModules: foo/bar, foo",
+			want:    []module{bar, foo},
+		},
+		{
+			title:   "whitespace separated",
+			message: "release: the things\n\nModules: foo/bar foo",
+			want:    []module{bar, foo},
+		},
+		{
+			title:   "newline separated",
+			message: "release: the things\n\nModules: foo/bar\n  foo/plugins/a,\nfoo",
+			want:    []module{bar, pluginA, foo},
+		},
+		{
+			title:   "repeated footers",
+			message: "release: the things\n\nModules: foo/plugins/b\nModules: foo/bar",
+			want:    []module{pluginB, bar},
+		},
+		{
+			title:   "module paths",
+			message: "release: the things\n\nModules: ./plugins/a, bar/, .",
+			want:    []module{pluginA, bar, foo},
+		},
+		{
+			title:   "name glob",
+			message: "release: the plugins\n\nModules: foo/plugins/*",
+			want:    []module{pluginA, pluginB},
+		},
+		{
+			title:   "path glob",
+			message: "release: the plugins\n\nModules: plugins/*",
+			want:    []module{pluginA, pluginB},
+		},
+		{
+			title:   "duplicates",
+			message: "release: the things\n\nModules: foo/plugins/a, plugins/*, plugins/a",
+			want:    []module{pluginA, pluginB},
+		},
+		{
+			title:   "unknown module",
+			message: "release: the things\n\nModules: foo/baz",
+			wantErr: "no module foo/baz found",
+		},
+		{
+			title:   "unmatched glob",
+			message: "release: the things\n\nModules: foo/baz/*",
+			wantErr: "no module matches foo/baz/*",
+		},
+		{
+			title:   "invalid glob",
+			message: "release: the things\n\nModules: foo/[",
+			wantErr: "invalid module pattern foo/[: syntax error in pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			c, err := commit.Parse(tt.message)
+			require.NoError(t, err)
+
+			g, _, _ := newGotagger(t)
+
+			got, _, err := g.extractCommitModules(git.Commit{Commit: c}, modules)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestGotagger_validateModules(t *testing.T) {
 	tests := []struct {
 		title   string
@@ -1625,9 +2630,10 @@ func newGotagger(t testutils.T) (g *Gotagger, repo *sgit.Repository, path string
 	}
 
 	g = &Gotagger{
-		Config: NewDefaultConfig(),
-		logger: logr.Discard(),
-		repo:   r,
+		Config:   NewDefaultConfig(),
+		logger:   logr.Discard(),
+		repo:     r,
+		warnings: io.Discard,
 	}
 
 	return
@@ -1780,3 +2786,108 @@ func untaggedV2Repo(t testutils.T, repo *sgit.Repository, path string) {
 	simpleGoRepo(t, repo, path)
 	testutils.CommitFile(t, repo, path, "go.mod", "feat!: now v2", []byte("module foo/v2\n"))
 }
+
+func TestGotagger_History(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	v2DirGitRepo(t, repo, path)
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "bar.go"), "fix: fix bar", []byte("bar"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "baz.go"), "feat: add baz", []byte("baz"))
+	testutils.CreateTag(t, repo, "bar/v1.1.0")
+
+	type summary struct {
+		module, tag string
+		commits     int
+	}
+
+	releases, err := g.History()
+	require.NoError(t, err)
+
+	var got []summary
+	for _, r := range releases {
+		assert.NotEmpty(t, r.Commit)
+		assert.False(t, r.Date.IsZero())
+		got = append(got, summary{r.Module, r.Tag, r.Commits})
+	}
+
+	assert.Equal(t, []summary{
+		{"foo", "v1.0.0", 1},
+		{"foo/v2", "v2.0.0", 1},
+		{"foo/bar", "bar/v1.0.0", 1},
+		{"foo/bar", "bar/v1.1.0", 2},
+		{"foo/bar/v2", "bar/v2.0.0", 1},
+	}, got)
+
+	if releases, err := g.History("foo/bar"); assert.NoError(t, err) {
+		if assert.Len(t, releases, 2) {
+			assert.Equal(t, "1.0.0", releases[0].Version)
+			assert.Equal(t, "1.1.0", releases[1].Version)
+		}
+	}
+}
+
+func TestGotagger_History_IgnoreModules(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.CreateTag(t, repo, "v1.1.0")
+
+	g.Config.IgnoreModules = true
+	if releases, err := g.History(); assert.NoError(t, err) {
+		if assert.Len(t, releases, 2) {
+			assert.Equal(t, Release{Path: ".", Tag: "v1.0.0", Version: "1.0.0", Commit: releases[0].Commit, Date: releases[0].Date, Commits: 2}, releases[0])
+			assert.Equal(t, "v1.1.0", releases[1].Tag)
+			assert.Equal(t, 1, releases[1].Commits)
+		}
+	}
+}
+
+func TestGotagger_FirstReleases(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	v2DirGitRepo(t, repo, path)
+	h := testutils.CommitFile(t, repo, path, filepath.Join("bar", "bar.go"), "fix: fix bar", []byte("bar"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "baz.go"), "feat: add baz", []byte("baz"))
+	testutils.CreateTag(t, repo, "bar/v1.1.0")
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "baz.go"), "feat: more baz", []byte("more baz"))
+	testutils.CreateTag(t, repo, "bar/v1.2.0")
+
+	if releases, err := g.FirstReleases(h.String()); assert.NoError(t, err) {
+		if assert.Len(t, releases, 1) {
+			assert.Equal(t, "bar/v1.1.0", releases[0].Tag)
+			assert.Equal(t, "foo/bar", releases[0].Module)
+		}
+	}
+
+	// an unreleased commit has no releases
+	h = testutils.CommitFile(t, repo, path, filepath.Join("bar", "baz.go"), "feat: unreleased baz", []byte("unreleased"))
+	if releases, err := g.FirstReleases(h.String()); assert.NoError(t, err) {
+		assert.Empty(t, releases)
+	}
+}
+
+func TestGotagger_LoadConfig(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	// no config file
+	if fn, err := g.LoadConfig(filepath.Join(path, "baz")); assert.NoError(t, err) {
+		assert.Empty(t, fn)
+	}
+
+	// config files are discovered from parent directories
+	require.NoError(t, os.WriteFile(filepath.Join(path, ".gotagger.yaml"), []byte("versionPrefix: release-\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "sub", "dir"), 0700))
+	if fn, err := g.LoadConfig(filepath.Join(path, "sub", "dir")); assert.NoError(t, err) {
+		want, err := filepath.EvalSymlinks(filepath.Join(path, ".gotagger.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, want, fn)
+		assert.Equal(t, "release-", g.Config.VersionPrefix)
+	}
+
+	// invalid config files are an error
+	require.NoError(t, os.WriteFile(filepath.Join(path, "sub", ".gotagger.toml"), []byte("versionPrefix = "), 0600))
+	_, err := g.LoadConfig(filepath.Join(path, "sub", "dir"))
+	assert.ErrorContains(t, err, "invalid config file")
+}
@@ -1,6 +1,7 @@
 package gotagger
 
 import (
+	"io"
 	"path/filepath"
 	"testing"
 
@@ -23,9 +24,10 @@ func TestWindowsPaths(t *testing.T) {
 	require.NoError(t, err)
 
 	g := &Gotagger{
-		Config: NewDefaultConfig(),
-		logger: logr.Discard(),
-		repo:   r,
+		Config:   NewDefaultConfig(),
+		logger:   logr.Discard(),
+		repo:     r,
+		warnings: io.Discard,
 	}
 
 	if versions, err := g.TagRepo(); assert.NoError(t, err) {
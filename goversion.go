@@ -0,0 +1,317 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultGoVersionFile is the default name of generated go version files.
+	DefaultGoVersionFile = "version.go"
+
+	// DefaultGoVersionVar is the default name of the version variable.
+	DefaultGoVersionVar = "Version"
+
+	// DefaultGoCommitVar is the default name of the commit variable.
+	DefaultGoCommitVar = "Commit"
+
+	// DefaultGoImportPath is the default import path of the package
+	// whose variables are set by linker flags.
+	DefaultGoImportPath = "main"
+)
+
+// GoVersion configures the go files written by GenerateGoVersion,
+// and the linker flags returned by LDFlags.
+//
+// Empty fields use their defaults.
+type GoVersion struct {
+	// File is the name of the file written to the directory of each go module.
+	// Defaults to DefaultGoVersionFile.
+	File string
+
+	// Package is the package name of the generated files.
+	// Defaults to the package of the go files in the directory of each module.
+	Package string
+
+	// Var is the name of the variable that holds the version.
+	// Defaults to DefaultGoVersionVar.
+	Var string
+
+	// CommitVar is the name of the variable that LDFlags sets to the commit hash.
+	// Defaults to DefaultGoCommitVar.
+	CommitVar string
+
+	// ImportPath is the import path of the package whose variables LDFlags sets.
+	// Defaults to DefaultGoImportPath.
+	ImportPath string
+}
+
+// GenerateGoVersion writes a go file that declares the current version
+// to the directory of each go module, and returns the paths of the files that
+// changed, relative to the top of the repository.
+//
+// If module names are passed in, then only the files of those modules are written.
+//
+// The version includes the version prefix, but not the module directory,
+// so the root module and the module in foo/bar are both at versions like v1.2.0.
+func (g *Gotagger) GenerateGoVersion(names ...string) ([]string, error) {
+	if !g.isHead() {
+		return nil, errors.New("go version files can only be generated at HEAD")
+	}
+
+	if err := g.Config.GoVersion.validate(); err != nil {
+		return nil, err
+	}
+
+	releases, err := g.goVersionReleases(names)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := g.repo.Toplevel()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := g.Config.GoVersion
+	var changed []string
+	for _, r := range releases {
+		dir := filepath.Join(root, r.module.path)
+		fn := filepath.Join(dir, cfg.file())
+		logger := g.logger.WithValues("module", r.module.name, "path", fn)
+
+		pkg := cfg.Package
+		if pkg == "" {
+			pkg, err = goPackageName(dir)
+			if err != nil {
+				return nil, fmt.Errorf("module %s: %w", r.module.name, err)
+			}
+		}
+
+		version, err := g.goVersion(r)
+		if err != nil {
+			return nil, err
+		}
+
+		data := goVersionSource(pkg, cfg.variable(), r.module.name, version)
+		if current, err := os.ReadFile(fn); err == nil && bytes.Equal(current, data) {
+			logger.Info("go version file is up to date", "version", version)
+			continue
+		}
+
+		logger.Info("writing go version file", "version", version)
+		if err := os.WriteFile(fn, data, 0o644); err != nil {
+			return nil, err
+		}
+		changed = append(changed, filepath.ToSlash(filepath.Join(r.module.path, cfg.file())))
+	}
+
+	return changed, nil
+}
+
+// LDFlags returns the linker flags that set the version and commit variables
+// of the go package, such as
+//
+//	-X main.Version=v1.2.0 -X main.Commit=0123456789abcdef0123456789abcdef01234567
+//
+// for each go module, in the same order as ModuleVersions.
+// The flags are passed to go build with -ldflags.
+//
+// If module names are passed in, then only the flags for those modules are
+// returned.
+func (g *Gotagger) LDFlags(names ...string) ([]string, error) {
+	if err := g.Config.GoVersion.validate(); err != nil {
+		return nil, err
+	}
+
+	releases, err := g.goVersionReleases(names)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := g.repo.RevParse(g.rev())
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := g.Config.GoVersion
+	flags := make([]string, len(releases))
+	for i, r := range releases {
+		version, err := g.goVersion(r)
+		if err != nil {
+			return nil, err
+		}
+
+		flags[i] = fmt.Sprintf("-X %s.%s=%s -X %s.%s=%s",
+			cfg.importPath(), cfg.variable(), version,
+			cfg.importPath(), cfg.commitVariable(), hash)
+	}
+
+	return flags, nil
+}
+
+// goVersionReleases returns the versions of the go modules named names,
+// or of every go module if names is empty.
+func (g *Gotagger) goVersionReleases(names []string) ([]release, error) {
+	if g.Config.IgnoreModules {
+		return nil, errors.New("go versions require go modules")
+	}
+
+	modules, err := g.findAllModules(names)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(modules) == 0 {
+		if len(names) > 0 {
+			return nil, fmt.Errorf("no go modules named %s", strings.Join(names, ", "))
+		}
+		return nil, errors.New("go versions require go modules")
+	}
+
+	return g.versions(modules, nil)
+}
+
+// goVersion returns the version of r in the output format,
+// without the directory of its module.
+func (g *Gotagger) goVersion(r release) (string, error) {
+	versions, err := g.outputVersions([]release{r})
+	if err != nil {
+		return "", err
+	}
+
+	version := versions[0]
+	if i := strings.LastIndex(version, "/"); i >= 0 {
+		version = version[i+1:]
+	}
+
+	return version, nil
+}
+
+// goVersionSource returns the source of a go file in package pkg
+// that declares the variable name set to version.
+func goVersionSource(pkg, name, module, version string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintln(&b, "// Code generated by gotagger. DO NOT EDIT.")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "package %s\n", pkg)
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "// %s is the version of %s.\n", name, module)
+	fmt.Fprintf(&b, "var %s = %s\n", name, strconv.Quote(version))
+
+	return b.Bytes()
+}
+
+// goPackageName returns the name of the package of the go files in dir.
+// Test files are ignored.
+func goPackageName(dir string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	fset := token.NewFileSet()
+	for _, fn := range files {
+		if strings.HasSuffix(fn, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, fn, nil, parser.PackageClauseOnly)
+		if err != nil {
+			return "", err
+		}
+
+		return f.Name.Name, nil
+	}
+
+	return "", errors.New("cannot determine the package name: no go files in the module directory")
+}
+
+// file returns the name of the generated go files.
+func (v GoVersion) file() string {
+	if v.File == "" {
+		return DefaultGoVersionFile
+	}
+
+	return v.File
+}
+
+// variable returns the name of the version variable.
+func (v GoVersion) variable() string {
+	if v.Var == "" {
+		return DefaultGoVersionVar
+	}
+
+	return v.Var
+}
+
+// commitVariable returns the name of the commit variable.
+func (v GoVersion) commitVariable() string {
+	if v.CommitVar == "" {
+		return DefaultGoCommitVar
+	}
+
+	return v.CommitVar
+}
+
+// importPath returns the import path of the package set by linker flags.
+func (v GoVersion) importPath() string {
+	if v.ImportPath == "" {
+		return DefaultGoImportPath
+	}
+
+	return v.ImportPath
+}
+
+// normalize returns v with fields that are set to their default cleared.
+func (v GoVersion) normalize() GoVersion {
+	if v.File == DefaultGoVersionFile {
+		v.File = ""
+	}
+	if v.Var == DefaultGoVersionVar {
+		v.Var = ""
+	}
+	if v.CommitVar == DefaultGoCommitVar {
+		v.CommitVar = ""
+	}
+	if v.ImportPath == DefaultGoImportPath {
+		v.ImportPath = ""
+	}
+
+	return v
+}
+
+// validate returns an error if v does not produce valid go.
+func (v GoVersion) validate() error {
+	if v.Package != "" && !token.IsIdentifier(v.Package) {
+		return fmt.Errorf("invalid package name: %s", v.Package)
+	}
+
+	for _, name := range []string{v.Var, v.CommitVar} {
+		if name != "" && !token.IsIdentifier(name) {
+			return fmt.Errorf("invalid variable name: %s", name)
+		}
+	}
+
+	if v.File != "" && (filepath.Ext(v.File) != ".go" || strings.ContainsAny(v.File, `/\`) || strings.HasSuffix(v.File, "_test.go")) {
+		return fmt.Errorf("invalid file name: %s", v.File)
+	}
+
+	if strings.ContainsAny(v.ImportPath, " \t\n") {
+		return fmt.Errorf("invalid import path: %s", v.ImportPath)
+	}
+
+	return nil
+}
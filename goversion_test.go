@@ -0,0 +1,189 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sassoftware/gotagger/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGotagger_GenerateGoVersion(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+	testutils.CommitFile(t, repo, path, "foo.go", "feat: add foo", []byte("package foo\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "go.mod"), "feat: add bar/go.mod", []byte("module foo/bar\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "bar_test.go"), "test: add bar tests", []byte("package bar_test\n"))
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "bar.go"), "feat: add bar", []byte("package bar\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CreateTag(t, repo, "bar/v0.1.0")
+	testutils.CommitFile(t, repo, path, filepath.Join("bar", "baz.go"), "fix: add baz", []byte("package bar\n"))
+
+	if files, err := g.GenerateGoVersion(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"version.go", "bar/version.go"}, files)
+	}
+
+	versionGo, err := os.ReadFile(filepath.Join(path, "version.go"))
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by gotagger. DO NOT EDIT.
+
+package foo
+
+// Version is the version of foo.
+var Version = "v1.0.0"
+`, string(versionGo))
+
+	barVersionGo, err := os.ReadFile(filepath.Join(path, "bar", "version.go"))
+	require.NoError(t, err)
+	assert.Equal(t, `// Code generated by gotagger. DO NOT EDIT.
+
+package bar
+
+// Version is the version of foo/bar.
+var Version = "v0.1.1"
+`, string(barVersionGo))
+
+	// generating again does not change anything
+	if files, err := g.GenerateGoVersion(); assert.NoError(t, err) {
+		assert.Empty(t, files)
+	}
+
+	// the package, variable, and file names are configurable
+	g.Config.GoVersion = GoVersion{File: "zz_version.go", Package: "version", Var: "AppVersion"}
+	if files, err := g.GenerateGoVersion(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"zz_version.go", "bar/zz_version.go"}, files)
+	}
+
+	versionGo, err = os.ReadFile(filepath.Join(path, "zz_version.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(versionGo), "package version\n")
+	assert.Contains(t, string(versionGo), "var AppVersion = \"v1.0.0\"\n")
+
+	// only the named modules are generated
+	g.Config.GoVersion = GoVersion{File: "other.go"}
+	if files, err := g.GenerateGoVersion("foo/bar"); assert.NoError(t, err) {
+		assert.Equal(t, []string{"bar/other.go"}, files)
+	}
+
+	g.Config.GoVersion = GoVersion{Var: "app-version"}
+	_, err = g.GenerateGoVersion()
+	assert.EqualError(t, err, "invalid variable name: app-version")
+
+	g.Config.GoVersion = GoVersion{}
+	g.Config.Rev = "HEAD~1"
+	_, err = g.GenerateGoVersion()
+	assert.EqualError(t, err, "go version files can only be generated at HEAD")
+}
+
+func TestGotagger_GenerateGoVersion_noPackage(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+
+	_, err := g.GenerateGoVersion()
+	assert.EqualError(t, err, "module foo: cannot determine the package name: no go files in the module directory")
+
+	g.Config.GoVersion.Package = "foo"
+	if files, err := g.GenerateGoVersion(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"version.go"}, files)
+	}
+}
+
+func TestGotagger_LDFlags(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "go.mod", "feat: add go.mod", []byte("module foo\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CommitFile(t, repo, path, "foo.go", "feat: add foo", []byte("package foo\n"))
+
+	hash, err := g.repo.RevParse("HEAD")
+	require.NoError(t, err)
+
+	if flags, err := g.LDFlags(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"-X main.Version=v1.1.0 -X main.Commit=" + hash}, flags)
+	}
+
+	g.Config.GoVersion = GoVersion{ImportPath: "foo/internal/version", Var: "AppVersion", CommitVar: "GitHash"}
+	if flags, err := g.LDFlags(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"-X foo/internal/version.AppVersion=v1.1.0 -X foo/internal/version.GitHash=" + hash}, flags)
+	}
+
+	_, err = g.LDFlags("bar")
+	assert.EqualError(t, err, "no go modules named bar")
+
+	// the version and commit of other revisions
+	g.Config.GoVersion = GoVersion{}
+	g.Config.Rev = "HEAD~1"
+	hash, err = g.repo.RevParse("HEAD~1")
+	require.NoError(t, err)
+
+	if flags, err := g.LDFlags(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"-X main.Version=v1.0.0 -X main.Commit=" + hash}, flags)
+	}
+
+	g.Config.IgnoreModules = true
+	_, err = g.LDFlags()
+	assert.EqualError(t, err, "go versions require go modules")
+}
+
+func TestGoVersion_validate(t *testing.T) {
+	tests := []struct {
+		title   string
+		version GoVersion
+		wantErr string
+	}{
+		{title: "defaults"},
+		{
+			title:   "custom",
+			version: GoVersion{File: "zz_version.go", Package: "version", Var: "AppVersion", CommitVar: "GitHash", ImportPath: "example.com/foo/version"},
+		},
+		{
+			title:   "invalid package",
+			version: GoVersion{Package: "my-package"},
+			wantErr: "invalid package name: my-package",
+		},
+		{
+			title:   "invalid commit var",
+			version: GoVersion{CommitVar: "1commit"},
+			wantErr: "invalid variable name: 1commit",
+		},
+		{
+			title:   "not a go file",
+			version: GoVersion{File: "version.txt"},
+			wantErr: "invalid file name: version.txt",
+		},
+		{
+			title:   "file in directory",
+			version: GoVersion{File: "internal/version.go"},
+			wantErr: "invalid file name: internal/version.go",
+		},
+		{
+			title:   "test file",
+			version: GoVersion{File: "version_test.go"},
+			wantErr: "invalid file name: version_test.go",
+		},
+		{
+			title:   "invalid import path",
+			version: GoVersion{ImportPath: "main -X foo"},
+			wantErr: "invalid import path: main -X foo",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			err := tt.version.validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sassoftware/gotagger/internal/git"
+)
+
+// Release represents a version of a go module, component, or path that was
+// released by tagging a commit.
+type Release struct {
+	// Module is the name of the go module.
+	// It is empty if gotagger is not versioning go modules.
+	Module string
+
+	// Component is the name of the component.
+	// It is empty if gotagger is not versioning components.
+	Component string
+
+	// Path is the path of the go module, or the path being versioned.
+	// It is empty for components.
+	Path string
+
+	// Tag is the name of the release tag.
+	Tag string
+
+	// Version is the released version, without the tag prefix.
+	Version string
+
+	// Commit is the hash of the tagged commit.
+	Commit string
+
+	// Date is the committer date of the tagged commit.
+	Date time.Time
+
+	// Commits is the number of commits that changed the module or path
+	// since the previous release.
+	Commits int
+}
+
+// History returns all of the releases of the go modules,
+// or components or paths if gotagger is not versioning go modules,
+// that are reachable from the configured revision.
+//
+// Releases are grouped by module, component, or path in the order they are found,
+// and ordered from oldest to newest version.
+//
+// If module names are passed in, then only the releases of those modules are
+// returned.
+func (g *Gotagger) History(names ...string) ([]Release, error) {
+	modules, err := g.historyModules(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []Release
+	if len(modules) > 0 {
+		for _, mod := range modules {
+			r, err := g.moduleHistory(mod, modules)
+			if err != nil {
+				return nil, err
+			}
+			releases = append(releases, r...)
+		}
+	} else if len(g.Config.Components) > 0 {
+		for _, component := range g.Config.Components {
+			r, err := g.componentHistory(component)
+			if err != nil {
+				return nil, err
+			}
+			releases = append(releases, r...)
+		}
+	} else {
+		paths := g.paths()
+		for _, p := range paths {
+			r, err := g.pathHistory(p, paths)
+			if err != nil {
+				return nil, err
+			}
+			releases = append(releases, r...)
+		}
+	}
+
+	return releases, nil
+}
+
+// FirstReleases returns the first release of each go module,
+// or component or path if gotagger is not versioning go modules,
+// that contains the commit rev.
+//
+// Only the modules, components, or paths changed by rev are considered.
+func (g *Gotagger) FirstReleases(rev string) ([]Release, error) {
+	c, err := g.commit(rev)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := g.repo.TagsContaining(c.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	containing := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		containing[tag] = struct{}{}
+	}
+
+	releases, err := g.History()
+	if err != nil {
+		return nil, err
+	}
+
+	// determine which modules, components, or paths the commit changed
+	changed := map[string]struct{}{}
+	modules, err := g.historyModules(nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) > 0 {
+		for mod := range g.groupCommitsByModule([]git.Commit{c}, modules) {
+			changed[mod.path] = struct{}{}
+		}
+	} else if len(g.Config.Components) > 0 {
+		for name := range g.groupCommitsByComponent([]git.Commit{c}) {
+			changed[name] = struct{}{}
+		}
+	} else {
+		for p := range g.groupCommitsByPath([]git.Commit{c}, g.paths()) {
+			changed[p] = struct{}{}
+		}
+	}
+
+	var first []Release
+	found := map[string]struct{}{}
+	for _, r := range releases {
+		key := r.Path
+		if r.Component != "" {
+			key = r.Component
+		}
+
+		if _, ok := changed[key]; !ok {
+			continue
+		}
+
+		if _, ok := found[key]; ok {
+			continue
+		}
+
+		if _, ok := containing[r.Tag]; ok {
+			g.logger.Info("found first release containing commit", "commit", c.Hash, "tag", r.Tag)
+			first = append(first, r)
+			found[key] = struct{}{}
+		}
+	}
+
+	return first, nil
+}
+
+func (g *Gotagger) historyModules(names []string) ([]module, error) {
+	if g.Config.IgnoreModules {
+		return nil, nil
+	}
+
+	return g.findAllModules(names)
+}
+
+func (g *Gotagger) moduleHistory(mod module, modules []module) ([]Release, error) {
+	g.logger.Info("finding releases for module", "module", mod.name)
+
+	mg := g.forModule(mod)
+	tags, err := g.tags(g.rev(), mod.prefix+mg.Config.VersionPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	_, candidates, err := mg.moduleTagVersions(tags, mod)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.history(candidates, Release{Module: mod.name, Path: mod.path}, func(hash, previous string) (int, error) {
+		commits, err := g.revList(hash, previous, mod.path)
+		if err != nil {
+			return 0, err
+		}
+
+		return len(g.groupCommitsByModule(commits, modules)[mod]), nil
+	})
+}
+
+func (g *Gotagger) pathHistory(p string, paths []string) ([]Release, error) {
+	g.logger.Info("finding releases for path", "path", p)
+
+	tags, err := g.tags(g.rev(), g.Config.VersionPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := g.pathTagVersions(tags, g.Config.VersionPrefix)
+
+	return g.history(candidates, Release{Path: p}, func(hash, previous string) (int, error) {
+		commits, err := g.revList(hash, previous, p)
+		if err != nil {
+			return 0, err
+		}
+
+		return len(g.groupCommitsByPath(commits, paths)[p]), nil
+	})
+}
+
+func (g *Gotagger) componentHistory(component Component) ([]Release, error) {
+	g.logger.Info("finding releases for component", "component", component.Name)
+
+	cg := g.forComponent(component)
+	prefix := cg.componentPrefix(component)
+	tags, err := g.tags(g.rev(), prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := cg.pathTagVersions(tags, prefix)
+
+	return cg.history(candidates, Release{Component: component.Name}, func(hash, previous string) (int, error) {
+		commits, err := g.revList(hash, previous, component.paths()...)
+		if err != nil {
+			return 0, err
+		}
+
+		return len(g.groupCommitsByComponent(commits)[component.Name]), nil
+	})
+}
+
+// history returns a Release for each of the candidates ordered by version.
+//
+// countCommits returns the number of commits between the hash of a release,
+// and the hash of the previous release.
+func (g *Gotagger) history(candidates []tagVersion, base Release, countCommits func(hash, previous string) (int, error)) ([]Release, error) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].version.LessThan(candidates[j].version)
+	})
+
+	releases := make([]Release, 0, len(candidates))
+	var previous string
+	for _, candidate := range candidates {
+		hash, err := g.repo.RevParse(candidate.tag + "^{commit}")
+		if err != nil {
+			return nil, err
+		}
+
+		date, err := g.repo.CommitDate(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		count, err := countCommits(hash, previous)
+		if err != nil {
+			return nil, err
+		}
+
+		r := base
+		r.Tag = candidate.tag
+		r.Version = g.strategy().Format(candidate.version)
+		r.Commit = hash
+		r.Date = date
+		r.Commits = count
+		releases = append(releases, r)
+
+		previous = hash
+	}
+
+	return releases, nil
+}
@@ -11,22 +11,51 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/sassoftware/gotagger/internal/commit"
+	"github.com/sassoftware/gotagger/commit"
 )
 
 var (
-	errEmptyStart = errors.New("Must specify a start")
+	errEmptyStart        = errors.New("Must specify a start")
+	errLightweightSigned = errors.New("lightweight tags cannot be signed")
 )
 
 // Commit represents a commit in a git repository.
+//
+// Merge is true if the commit has more than one parent,
+// whatever its message says.
 type Commit struct {
 	commit.Commit
-	Hash    string
+	Hash string
+
+	// Tree is the hash of the tree of the commit.
+	Tree string
+
+	// Parents are the hashes of the parents of the commit, in order.
+	Parents []string
+
+	// Author is who wrote the change, and when.
+	Author Signature
+
+	// Committer is who created the commit, and when.
+	Committer Signature
+
+	// Signed is true if the commit has a signature.
+	// The signature is not verified.
+	Signed bool
+
 	Changes []Change
 }
 
+// Signature identifies the author or committer of a commit.
+type Signature struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
 type Change struct {
 	SourceName string
 	DestName   string
@@ -46,6 +75,21 @@ type Repository struct {
 	logger logr.Logger
 }
 
+// HistoryOptions controls which commits RevList returns, how Commit and RevList
+// parse commit messages, and which tags Tags returns.
+type HistoryOptions struct {
+	// FirstParent only follows the first parent of merge commits,
+	// so the commits and tags of merged branches are skipped.
+	FirstParent bool
+
+	// NoMerges skips merge commits.
+	NoMerges bool
+
+	// Parser parses commit messages.
+	// A nil Parser parses Conventional Commits.
+	Parser commit.Parser
+}
+
 // New returns a new git Repo. If path is not a git repo, then an error will be returned.
 func New(path string) (*Repository, error) {
 	gitDir, err := getGitDirectory(path)
@@ -72,18 +116,58 @@ func New(path string) (*Repository, error) {
 	return repo, nil
 }
 
+// TagOptions controls how CreateTag creates a tag.
+type TagOptions struct {
+	// Message is the tag annotation. Defaults to "Release " followed by the tag name.
+	Message string
+
+	// Sign controls whether the tag is signed.
+	Sign bool
+
+	// SigningKey is the key used to sign the tag.
+	// If empty, then git uses the default key for the committer.
+	SigningKey string
+
+	// SigningFormat is the signature format git should use:
+	// openpgp, x509, or ssh.
+	// If empty, then git uses the gpg.format git config setting.
+	SigningFormat string
+
+	// Lightweight controls whether to create a lightweight tag instead of an
+	// annotated tag. Lightweight tags cannot be signed and have no message.
+	Lightweight bool
+}
+
 // CreateTag tags a commit in a git repo.
-//
-// If prefix is a non-empty string, then the version will be prefixed with that string.
-func (r *Repository) CreateTag(hash, name, message string, signed bool) error {
+func (r *Repository) CreateTag(hash, name string, opts TagOptions) error {
 	r.logger.V(1).Info("creating tag")
 
+	if opts.Lightweight {
+		if opts.Sign || opts.SigningKey != "" {
+			return errLightweightSigned
+		}
+
+		r.logger.V(1).Info("creating lightweight tag")
+		_, err := r.run([]string{"tag", name, hash})
+		return err
+	}
+
+	message := opts.Message
 	if message == "" {
 		message = "Release " + name
 	}
 
-	args := []string{"tag"}
-	if signed {
+	var args []string
+	if opts.SigningFormat != "" {
+		args = append(args, "-c", "gpg.format="+opts.SigningFormat)
+	}
+
+	args = append(args, "tag")
+	switch {
+	case opts.SigningKey != "":
+		r.logger.V(1).Info("signing tag", "key", opts.SigningKey)
+		args = append(args, "-u", opts.SigningKey)
+	case opts.Sign:
 		r.logger.V(1).Info("signing tag")
 		args = append(args, "-s")
 	}
@@ -113,17 +197,55 @@ func (r *Repository) DeleteTags(tags []string) error {
 	return nil
 }
 
-// Head returns the commit at HEAD
-func (r *Repository) Head() (c Commit, err error) {
-	r.logger.V(1).Info("getting HEAD commit")
-	out, err := r.run([]string{"show", "--format=raw", "--raw", "--no-abbrev", "HEAD"})
+// Commit returns the commit at rev.
+func (r *Repository) Commit(rev string, opts HistoryOptions) (c Commit, err error) {
+	r.logger.V(1).Info("getting commit", "rev", rev)
+	args := []string{"show", "--format=raw", "--raw", "--no-abbrev"}
+	if opts.FirstParent {
+		args = append(args, "--diff-merges=first-parent")
+	}
+
+	out, err := r.run(append(args, rev))
 	if err != nil {
 		return Commit{}, err
 	}
 
 	out = strings.TrimSpace(out)
 
-	return parseCommit(out), nil
+	return parseCommit(out, opts.Parser), nil
+}
+
+// CommitDate returns the committer date of the commit at rev.
+func (r *Repository) CommitDate(rev string) (time.Time, error) {
+	out, err := r.run([]string{"log", "-1", "--format=%cI", rev})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Parse(time.RFC3339, strings.TrimSpace(out))
+}
+
+// Files returns the paths of all files in the tree of rev.
+//
+// Paths are relative to the root of the repository and always use '/' as the separator.
+func (r *Repository) Files(rev string) ([]string, error) {
+	r.logger.V(1).Info("listing files", "rev", rev)
+	out, err := r.run([]string{"ls-tree", "-r", "--full-tree", "--name-only", rev})
+	if err != nil {
+		return nil, err
+	}
+
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return nil, nil
+	}
+
+	return strings.Split(out, "\n"), nil
+}
+
+// Head returns the commit at HEAD
+func (r *Repository) Head() (c Commit, err error) {
+	return r.Commit("HEAD", HistoryOptions{})
 }
 
 // IsDirty returns a boolean indicating whether there are uncommited changes.
@@ -152,15 +274,30 @@ func (r *Repository) PushTags(tags []string, remote string) error {
 }
 
 // RevList returns a slice of commits from start to end.
-func (r *Repository) RevList(start, end string, paths ...string) ([]Commit, error) {
+//
+// In first-parent mode, the changes of merge commits are the changes
+// from their first parent. Otherwise merge commits have no changes.
+func (r *Repository) RevList(start, end string, opts HistoryOptions, paths ...string) ([]Commit, error) {
 	if start == "" {
 		return nil, errEmptyStart
 	}
 
-	args := []string{"log", "--format=raw", "--raw", "--no-abbrev", start}
+	args := []string{"log", "--format=raw", "--raw", "--no-abbrev"}
+
+	logger := r.logger.V(1)
+	if opts.FirstParent {
+		logger = logger.WithValues("firstParent", true)
+		args = append(args, "--first-parent", "--diff-merges=first-parent")
+	}
+
+	if opts.NoMerges {
+		logger = logger.WithValues("noMerges", true)
+		args = append(args, "--no-merges")
+	}
 
 	// add start and end refs
-	logger := r.logger.V(1).WithValues("start", start)
+	logger = logger.WithValues("start", start)
+	args = append(args, start)
 	if end != "" {
 		logger = logger.WithValues("end", end)
 		args = append(args, "^"+end)
@@ -184,7 +321,7 @@ func (r *Repository) RevList(start, end string, paths ...string) ([]Commit, erro
 		return []Commit{}, nil
 	}
 
-	return parseCommits(string(out)), nil
+	return parseCommits(string(out), opts.Parser), nil
 }
 
 func (r *Repository) RevParse(rev string) (string, error) {
@@ -196,17 +333,99 @@ func (r *Repository) RevParse(rev string) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
+// ReadFile returns the contents of the file at path in the tree of rev.
+//
+// path is relative to the root of the repository and must use '/' as the separator.
+func (r *Repository) ReadFile(rev, path string) ([]byte, error) {
+	r.logger.V(1).Info("reading file", "rev", rev, "path", path)
+	out, err := r.run([]string{"show", rev + ":" + path})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(out), nil
+}
+
+// RemoteTags returns a map of tag name to commit hash for all tags in the
+// remote repository remote.
+//
+// Annotated tags are peeled, so the hash is always the hash of the tagged commit.
+func (r *Repository) RemoteTags(remote string) (map[string]string, error) {
+	r.logger.V(1).Info("getting remote tags", "remote", remote)
+	out, err := r.run([]string{"ls-remote", "--tags", remote})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			continue
+		}
+
+		hash, name := parts[0], strings.TrimPrefix(parts[1], "refs/tags/")
+		if peeled := strings.TrimSuffix(name, "^{}"); peeled != name {
+			// peeled entries always follow the tag object
+			tags[peeled] = hash
+		} else if _, ok := tags[name]; !ok {
+			tags[name] = hash
+		}
+	}
+
+	return tags, nil
+}
+
+// Remotes returns the names of the remote repositories.
+func (r *Repository) Remotes() ([]string, error) {
+	r.logger.V(1).Info("listing remotes")
+	out, err := r.run([]string{"remote"})
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(out), nil
+}
+
 // SetLogger updates the Repository's internal logger.
 func (r *Repository) SetLogger(l logr.Logger) {
 	r.logger = l
 }
 
+// TagCommits returns a map of tag name to commit hash for all local tags.
+//
+// Annotated tags are peeled, so the hash is always the hash of the tagged commit.
+func (r *Repository) TagCommits() (map[string]string, error) {
+	r.logger.V(1).Info("getting local tags")
+	out, err := r.run([]string{"for-each-ref", "--format=%(refname) %(objectname) %(*objectname)", "refs/tags"})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+
+		name, hash := strings.TrimPrefix(parts[0], "refs/tags/"), parts[1]
+		if len(parts) > 2 {
+			// annotated tag, so use the hash of the commit
+			hash = parts[2]
+		}
+		tags[name] = hash
+	}
+
+	return tags, nil
+}
+
 // Tags returns all tags that point to ancestors of rev.
 //
 // rev can be either a revision or a hash.
 //
 // prefix is a string prefix to filter tags with.
-func (r *Repository) Tags(rev string, prefixes ...string) (tags []string, err error) {
+func (r *Repository) Tags(rev string, opts HistoryOptions, prefixes ...string) (tags []string, err error) {
 	// list all tags that point to ancestors of rev
 	args := []string{"tag", "--merged", rev}
 	if len(prefixes) > 0 {
@@ -230,9 +449,79 @@ func (r *Repository) Tags(rev string, prefixes ...string) (tags []string, err er
 		tags = strings.Split(string(out), "\n")
 	}
 
+	if opts.FirstParent && len(tags) > 0 {
+		return r.firstParentTags(rev, tags)
+	}
+
+	return
+}
+
+// firstParentTags returns the tags that point to commits that are reachable
+// from rev by only following the first parent of merge commits.
+func (r *Repository) firstParentTags(rev string, tags []string) ([]string, error) {
+	r.logger.V(1).Info("filtering tags by first parent", "from", rev)
+	out, err := r.run([]string{"rev-list", "--first-parent", rev})
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make(map[string]bool)
+	for _, hash := range strings.Fields(out) {
+		commits[hash] = true
+	}
+
+	tagCommits, err := r.TagCommits()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []string
+	for _, tag := range tags {
+		if commits[tagCommits[tag]] {
+			filtered = append(filtered, tag)
+		}
+	}
+
+	return filtered, nil
+}
+
+// VerifyTag verifies the signature of the tag name.
+//
+// An error is returned if the tag is not signed or the signature is not valid.
+func (r *Repository) VerifyTag(name string) error {
+	r.logger.V(1).Info("verifying tag", "tag", name)
+	_, err := r.run([]string{"tag", "-v", name})
+	return err
+}
+
+// TagsContaining returns all tags that point to commits that contain rev.
+func (r *Repository) TagsContaining(rev string) (tags []string, err error) {
+	r.logger.V(1).Info("getting tags containing", "rev", rev)
+	out, err := r.run([]string{"tag", "--contains", rev})
+	if err != nil {
+		return
+	}
+
+	out = strings.TrimSpace(out)
+	if out != "" {
+		tags = strings.Split(out, "\n")
+	}
+
 	return
 }
 
+// Toplevel returns the absolute path of the top-level directory of the worktree.
+func (r *Repository) Toplevel() (string, error) {
+	args := []string{"rev-parse", "--show-toplevel"}
+	r.logger.V(1).Info("running git command", "args", strings.Join(args, " "))
+	out, err := r.runner(args, r.Path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
 func (r *Repository) run(args []string) (string, error) {
 	args = append([]string{"--git-dir", r.GitDir}, args...)
 	r.logger.V(1).Info("running git command", "args", strings.Join(args, " "))
@@ -290,7 +579,7 @@ func parseChanges(lines []string) []Change {
 	return changes
 }
 
-func parseCommit(data string) Commit {
+func parseCommit(data string, parser commit.Parser) Commit {
 	// strip the leading 'commit '
 	data = strings.TrimPrefix(data, "commit ")
 
@@ -310,19 +599,96 @@ func parseCommit(data string) Commit {
 	message = strings.TrimSpace(message)
 	message = strings.ReplaceAll(message, "\n    ", "\n")
 
-	// parse the commit message
-	return Commit{
-		Commit:  commit.Parse(message),
-		Hash:    strings.Split(headers, "\n")[0],
+	// parse the commit message.
+	// messages that do not follow the convention have no type,
+	// but keep their footers, so trailers like Release-Ignore still apply
+	if parser == nil {
+		parser = commit.Conventional{}
+	}
+	c, err := parser.Parse(message)
+	if err != nil {
+		c.Footers = commit.ParseFooters(message)
+	}
+
+	lines := strings.Split(headers, "\n")
+	gc := Commit{
+		Hash:    lines[0],
 		Changes: changes,
 	}
+	parseHeaders(&gc, lines[1:])
+
+	// merge commits are commits with several parents
+	c.Merge = len(gc.Parents) > 1
+	gc.Commit = c
+
+	return gc
+}
+
+// parseHeaders parses the headers of a raw commit into c:
+//
+//	tree <hash>
+//	parent <hash>
+//	author <name> <<email>> <unix time> <zone>
+//	committer <name> <<email>> <unix time> <zone>
+//	gpgsig <signature>
+//
+// Headers that continue on the following lines, such as gpgsig,
+// indent those lines with a space.
+func parseHeaders(c *Commit, lines []string) {
+	for _, line := range lines {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "tree":
+			c.Tree = value
+		case "parent":
+			c.Parents = append(c.Parents, value)
+		case "author":
+			c.Author = parseSignature(value)
+		case "committer":
+			c.Committer = parseSignature(value)
+		case "gpgsig", "gpgsig-sha256":
+			c.Signed = true
+		}
+	}
+}
+
+// parseSignature parses the value of an author or committer header:
+//
+//	Name <email> 1600000000 +0000
+func parseSignature(value string) (sig Signature) {
+	start, end := strings.IndexByte(value, '<'), strings.LastIndexByte(value, '>')
+	if start < 0 || end < start {
+		sig.Name = strings.TrimSpace(value)
+		return
+	}
+
+	sig.Name = strings.TrimSpace(value[:start])
+	sig.Email = value[start+1 : end]
+
+	fields := strings.Fields(value[end+1:])
+	if len(fields) != 2 {
+		return
+	}
+
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return
+	}
+
+	sig.When = time.Unix(seconds, 0)
+	if zone, err := time.Parse("-0700", fields[1]); err == nil {
+		_, offset := zone.Zone()
+		sig.When = sig.When.In(time.FixedZone(fields[1], offset))
+	}
+
+	return
 }
 
-func parseCommits(data string) (commits []Commit) {
+func parseCommits(data string, parser commit.Parser) (commits []Commit) {
 	// split on \ncommit to separate the raw output into raw commits
 	rawCommits := strings.Split(data, "\ncommit ")
 	for _, rawCommit := range rawCommits {
-		commits = append(commits, parseCommit(rawCommit))
+		commits = append(commits, parseCommit(rawCommit, parser))
 	}
 
 	return
@@ -9,8 +9,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/sassoftware/gotagger/commit"
 	"github.com/sassoftware/gotagger/internal/testutils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,25 +37,35 @@ func TestNew_no_repo(t *testing.T) {
 
 func TestCreateTag(t *testing.T) {
 	tests := []struct {
-		message string
-		signed  bool
-		want    []string
+		opts TagOptions
+		want []string
 	}{
 		{
 			want: []string{"--git-dir", ".git", "tag", "-m", "Release v1.0.0", "v1.0.0", "hash"},
 		},
 		{
-			message: "message",
-			want:    []string{"--git-dir", ".git", "tag", "-m", "message", "v1.0.0", "hash"},
+			opts: TagOptions{Message: "message"},
+			want: []string{"--git-dir", ".git", "tag", "-m", "message", "v1.0.0", "hash"},
+		},
+		{
+			opts: TagOptions{Message: "message", Sign: true},
+			want: []string{"--git-dir", ".git", "tag", "-s", "-m", "message", "v1.0.0", "hash"},
+		},
+		{
+			opts: TagOptions{Sign: true},
+			want: []string{"--git-dir", ".git", "tag", "-s", "-m", "Release v1.0.0", "v1.0.0", "hash"},
+		},
+		{
+			opts: TagOptions{Sign: true, SigningKey: "keyid"},
+			want: []string{"--git-dir", ".git", "tag", "-u", "keyid", "-m", "Release v1.0.0", "v1.0.0", "hash"},
 		},
 		{
-			message: "message",
-			signed:  true,
-			want:    []string{"--git-dir", ".git", "tag", "-s", "-m", "message", "v1.0.0", "hash"},
+			opts: TagOptions{Sign: true, SigningFormat: "ssh"},
+			want: []string{"--git-dir", ".git", "-c", "gpg.format=ssh", "tag", "-s", "-m", "Release v1.0.0", "v1.0.0", "hash"},
 		},
 		{
-			signed: true,
-			want:   []string{"--git-dir", ".git", "tag", "-s", "-m", "Release v1.0.0", "v1.0.0", "hash"},
+			opts: TagOptions{Message: "message", Lightweight: true},
+			want: []string{"--git-dir", ".git", "tag", "v1.0.0", "hash"},
 		},
 	}
 
@@ -63,11 +75,171 @@ func TestCreateTag(t *testing.T) {
 			tt := tt
 
 			r := &Repository{GitDir: ".git", Path: "path", runner: mockRunGitCommand(t, tt.want, "path"), logger: logr.Discard()}
-			_ = r.CreateTag("hash", "v1.0.0", tt.message, tt.signed)
+			_ = r.CreateTag("hash", "v1.0.0", tt.opts)
 		})
 	}
 }
 
+func TestCreateTag_lightweight_signed(t *testing.T) {
+	r := &Repository{GitDir: ".git", Path: "path", runner: mockRunGitCommand(t, nil, "path"), logger: logr.Discard()}
+	assert.Equal(t, errLightweightSigned, r.CreateTag("hash", "v1.0.0", TagOptions{Lightweight: true, Sign: true}))
+}
+
+func TestCommit(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	if c, err := r.Commit("other", HistoryOptions{}); assert.NoError(t, err) {
+		assert.Equal(t, "feat: commit a baz", c.Message())
+		assert.Equal(t, testutils.GotaggerEmail, c.Author.Email)
+	}
+}
+
+func TestCommit_merge(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: foo", []byte("foo"))
+	testutils.Git(t, path, "checkout", "-q", "-b", "feature")
+	testutils.CommitFile(t, repo, path, "bar", `Merge "feat: not a merge"`, []byte("bar"))
+	testutils.Git(t, path, "checkout", "-q", "-")
+	testutils.MergeBranch(t, path, "feature", "feat: merge the feature branch")
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	head, err := r.RevParse("HEAD")
+	require.NoError(t, err)
+	first, err := r.RevParse("HEAD^1")
+	require.NoError(t, err)
+	second, err := r.RevParse("HEAD^2")
+	require.NoError(t, err)
+
+	// merges are detected by their parents, not their message
+	if c, err := r.Commit("HEAD", HistoryOptions{}); assert.NoError(t, err) {
+		assert.Equal(t, head, c.Hash)
+		assert.Equal(t, []string{first, second}, c.Parents)
+		assert.True(t, c.Merge)
+		assert.Equal(t, "feat", c.Type)
+		assert.Equal(t, testutils.GotaggerName, c.Committer.Name)
+		assert.False(t, c.Signed)
+	}
+
+	if c, err := r.Commit("feature", HistoryOptions{}); assert.NoError(t, err) {
+		assert.Equal(t, []string{first}, c.Parents)
+		assert.False(t, c.Merge)
+		assert.Equal(t, "feat", c.Type)
+	}
+}
+
+func TestCommit_parser(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.CommitFile(t, repo, path, "foo", ":sparkles: add foo", []byte("foo"))
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	if c, err := r.Commit("HEAD", HistoryOptions{}); assert.NoError(t, err) {
+		assert.Equal(t, "", c.Type)
+	}
+
+	if c, err := r.Commit("HEAD", HistoryOptions{Parser: &commit.Gitmoji{}}); assert.NoError(t, err) {
+		assert.Equal(t, "feat", c.Type)
+	}
+}
+
+func TestParseCommit(t *testing.T) {
+	data := `commit 1111111111111111111111111111111111111111
+tree 2222222222222222222222222222222222222222
+parent 3333333333333333333333333333333333333333
+parent 4444444444444444444444444444444444444444
+author Jane Doe <jane@example.com> 1600000000 +0200
+committer GitHub <noreply@github.com> 1600000060 -0500
+gpgsig -----BEGIN PGP SIGNATURE-----
+ 
+ c2lnbmF0dXJl
+ -----END PGP SIGNATURE-----
+
+    Merge pull request #1 from jane/feature
+    
+    feat: add a feature
+
+:000000 100644 0000000000000000000000000000000000000000 5555555555555555555555555555555555555555 A	foo`
+
+	c := parseCommit(data, nil)
+
+	assert.Equal(t, "1111111111111111111111111111111111111111", c.Hash)
+	assert.Equal(t, "2222222222222222222222222222222222222222", c.Tree)
+	assert.Equal(t, []string{"3333333333333333333333333333333333333333", "4444444444444444444444444444444444444444"}, c.Parents)
+	assert.Equal(t, "Jane Doe", c.Author.Name)
+	assert.Equal(t, "jane@example.com", c.Author.Email)
+	assert.Equal(t, "2020-09-13T14:26:40+02:00", c.Author.When.Format(time.RFC3339))
+	assert.Equal(t, "GitHub", c.Committer.Name)
+	assert.Equal(t, "noreply@github.com", c.Committer.Email)
+	assert.Equal(t, "2020-09-13T07:27:40-05:00", c.Committer.When.Format(time.RFC3339))
+	assert.True(t, c.Signed)
+	assert.True(t, c.Merge)
+	assert.Equal(t, "feat", c.Type)
+	assert.Equal(t, "1", c.PullRequest)
+	if assert.Len(t, c.Changes, 1) {
+		assert.Equal(t, "foo", c.Changes[0].SourceName)
+	}
+}
+
+func TestParseCommit_not_conventional(t *testing.T) {
+	data := `commit 1111111111111111111111111111111111111111
+tree 2222222222222222222222222222222222222222
+author Bot <bot@example.com> 1600000000 +0000
+committer Bot <bot@example.com> 1600000000 +0000
+
+    Bump foo from 1 to 2
+    
+    Release-Ignore: true`
+
+	c := parseCommit(data, nil)
+
+	// the footers are parsed even though the header is not
+	assert.Equal(t, "", c.Type)
+	assert.Equal(t, []commit.Footer{{Title: "Release-Ignore", Separator: commit.ColonSeparator, Text: "true"}}, c.Footers)
+}
+
+func TestCommitDate(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	h := testutils.CommitFile(t, repo, path, "foo", "feat: add foo", []byte("foo\n"))
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	c, err := repo.CommitObject(h)
+	require.NoError(t, err)
+
+	if date, err := r.CommitDate(h.String()); assert.NoError(t, err) {
+		assert.True(t, c.Committer.When.Equal(date), "want %s, got %s", c.Committer.When, date)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	if files, err := r.Files("HEAD"); assert.NoError(t, err) {
+		assert.Equal(t, []string{"bar", "foo"}, files)
+	}
+
+	if files, err := r.Files("other"); assert.NoError(t, err) {
+		assert.Equal(t, []string{"baz/foo", "foo"}, files)
+	}
+}
+
 func TestHead(t *testing.T) {
 	repo, path := testutils.NewGitRepo(t)
 
@@ -192,7 +364,7 @@ func TestPushTag_no_remote(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := r.CreateTag(head.Hash, "tag", "", false); err != nil {
+	if err := r.CreateTag(head.Hash, "tag", TagOptions{}); err != nil {
 		t.Fatal(err)
 	}
 
@@ -203,6 +375,55 @@ func TestPushTag_no_remote(t *testing.T) {
 
 }
 
+func TestReadFile(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	if data, err := r.ReadFile("v1.0.0", "foo"); assert.NoError(t, err) {
+		assert.Equal(t, []byte("foo more"), data)
+	}
+
+	if _, err := r.ReadFile("v1.0.0", "missing"); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "path 'missing' does not exist in 'v1.0.0'")
+	}
+}
+
+func TestRemoteTags(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.AddRemote(t, repo, "origin")
+	testutils.PushTag(t, repo, "origin", "v1.0.0")
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	want, err := r.RevParse("v1.0.0^{commit}")
+	require.NoError(t, err)
+
+	if tags, err := r.RemoteTags("origin"); assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"v1.0.0": want}, tags)
+	}
+}
+
+func TestRemoteTags_no_tags(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+	testutils.AddRemote(t, repo, "origin")
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	if tags, err := r.RemoteTags("origin"); assert.NoError(t, err) {
+		assert.Empty(t, tags)
+	}
+}
+
 func TestRevList(t *testing.T) {
 	tests := []struct {
 		start, end string
@@ -266,13 +487,64 @@ func TestRevList(t *testing.T) {
 
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("%d:%v", i, tt), func(t *testing.T) {
-			if commits, err := r.RevList(tt.start, tt.end, tt.paths...); assert.NoError(t, err) {
+			if commits, err := r.RevList(tt.start, tt.end, HistoryOptions{}, tt.paths...); assert.NoError(t, err) {
 				assert.Equal(t, tt.want, len(commits))
 			}
 		})
 	}
 }
 
+func TestRevList_history(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.CommitFile(t, repo, path, "foo", "feat: foo", []byte("foo"))
+	testutils.Git(t, path, "checkout", "-q", "-b", "feature")
+	testutils.CommitFile(t, repo, path, "feature", "feat: wip", []byte("wip"))
+	testutils.CommitFile(t, repo, path, "feature", "fix: more wip", []byte("more wip"))
+	testutils.Git(t, path, "checkout", "-q", "-")
+	testutils.CommitFile(t, repo, path, "foo", "fix: more foo", []byte("more foo"))
+	testutils.MergeBranch(t, path, "feature", "Merge pull request #1 from feature\n\nfeat: add feature")
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		title   string
+		history HistoryOptions
+		want    []string
+	}{
+		{
+			title: "all commits",
+			want:  []string{"add feature", "more foo", "more wip", "wip", "foo"},
+		},
+		{
+			title:   "first parent",
+			history: HistoryOptions{FirstParent: true},
+			want:    []string{"add feature", "more foo", "foo"},
+		},
+		{
+			title:   "no merges",
+			history: HistoryOptions{NoMerges: true},
+			want:    []string{"more foo", "more wip", "wip", "foo"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			commits, err := r.RevList("HEAD", "", tt.history)
+			require.NoError(t, err)
+
+			var got []string
+			for _, c := range commits {
+				got = append(got, c.Subject)
+			}
+			// commits in the same second are not ordered
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
 func TestRevList_one_commit(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -284,11 +556,11 @@ func TestRevList_one_commit(t *testing.T) {
 	r, err := New(path)
 	require.NoError(err)
 
-	if commits, err := r.RevList("HEAD", ""); assert.NoError(err) {
+	if commits, err := r.RevList("HEAD", "", HistoryOptions{}); assert.NoError(err) {
 		assert.Equal(1, len(commits))
 	}
 
-	if _, err := r.RevList("HEAD", "HEAD~1"); assert.Error(err) {
+	if _, err := r.RevList("HEAD", "HEAD~1", HistoryOptions{}); assert.Error(err) {
 		assert.Contains(err.Error(), "bad revision '^HEAD~1")
 	}
 }
@@ -302,11 +574,11 @@ func TestRevList_empty_repo(t *testing.T) {
 	r, err := New(path)
 	require.NoError(err)
 
-	if _, err := r.RevList("HEAD", ""); assert.Error(err) {
+	if _, err := r.RevList("HEAD", "", HistoryOptions{}); assert.Error(err) {
 		assert.Contains(err.Error(), "unknown revision")
 	}
 
-	if _, err := r.RevList("HEAD", "HEAD^"); assert.Error(err) {
+	if _, err := r.RevList("HEAD", "HEAD^", HistoryOptions{}); assert.Error(err) {
 		assert.Contains(err.Error(), "unknown revision")
 	}
 }
@@ -321,12 +593,61 @@ func TestRevList_empty_start(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, err = r.RevList("", "")
+	_, err = r.RevList("", "", HistoryOptions{})
 	if got, want := err, errEmptyStart; got != want {
 		t.Errorf("RevList(\"\", \"\") returned an error %v, want %v", got, want)
 	}
 }
 
+func TestTagCommits(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	v1, err := r.RevParse("v1.0.0^{commit}")
+	require.NoError(t, err)
+
+	v01, err := r.RevParse("v0.1.0^{commit}")
+	require.NoError(t, err)
+
+	if tags, err := r.TagCommits(); assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"v1.0.0": v1, "v0.1.0": v01}, tags)
+	}
+}
+
+func TestToplevel(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.CommitFile(t, repo, path, filepath.Join("sub", "foo"), "feat: add foo", []byte("foo\n"))
+
+	r, err := New(filepath.Join(path, "sub"))
+	require.NoError(t, err)
+
+	want, err := filepath.EvalSymlinks(path)
+	require.NoError(t, err)
+
+	if got, err := r.Toplevel(); assert.NoError(t, err) {
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestVerifyTag(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	// tags created by SimpleGitRepo are not signed
+	if err := r.VerifyTag("v1.0.0"); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no signature found")
+	}
+}
+
 func TestTags(t *testing.T) {
 	repo, path := testutils.NewGitRepo(t)
 
@@ -337,7 +658,7 @@ func TestTags(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tags, err := r.Tags("master")
+	tags, err := r.Tags("master", HistoryOptions{})
 	if err != nil {
 		if eerr, ok := err.(*exec.ExitError); ok {
 			t.Fatal(string(eerr.Stderr))
@@ -352,6 +673,23 @@ func TestTags(t *testing.T) {
 	}
 }
 
+func TestTagsContaining(t *testing.T) {
+	repo, path := testutils.NewGitRepo(t)
+
+	testutils.SimpleGitRepo(t, repo, path)
+
+	r, err := New(path)
+	require.NoError(t, err)
+
+	if tags, err := r.TagsContaining("master~2"); assert.NoError(t, err) {
+		assert.Equal(t, []string{"v0.1.0", "v1.0.0"}, tags)
+	}
+
+	if tags, err := r.TagsContaining("master"); assert.NoError(t, err) {
+		assert.Empty(t, tags)
+	}
+}
+
 func TestTags_no_tags(t *testing.T) {
 	repo, path := testutils.NewGitRepo(t)
 
@@ -360,7 +698,7 @@ func TestTags_no_tags(t *testing.T) {
 	r, err := New(path)
 	require.NoError(t, err)
 
-	if got, err := r.Tags("HEAD"); assert.NoError(t, err) {
+	if got, err := r.Tags("HEAD", HistoryOptions{}); assert.NoError(t, err) {
 		assert.Empty(t, got)
 	}
 }
@@ -380,7 +718,7 @@ func TestTags_prefixes(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tags, err := r.Tags("master", submodule+"/")
+	tags, err := r.Tags("master", HistoryOptions{}, submodule+"/")
 	if err != nil {
 		if eerr, ok := err.(*exec.ExitError); ok {
 			t.Fatal(string(eerr.Stderr))
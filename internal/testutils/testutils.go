@@ -5,10 +5,12 @@ package testutils
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/require"
@@ -34,6 +36,25 @@ type FileCommit struct {
 	Contents []byte
 }
 
+// AddRemote creates a bare repository and adds it to repo as the remote name.
+func AddRemote(t T, repo *git.Repository, name string) (remote *git.Repository, path string) {
+	t.Helper()
+
+	path = t.TempDir()
+
+	var err error
+	remote, err = git.PlainInit(path, true)
+	require.NoError(t, err)
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: name,
+		URLs: []string{path},
+	})
+	require.NoError(t, err)
+
+	return
+}
+
 func CommitFile(t T, repo *git.Repository, path, filename, message string, data []byte) plumbing.Hash {
 	t.Helper()
 
@@ -98,6 +119,36 @@ func CreateTag(t T, r *git.Repository, name string) {
 	}
 }
 
+// PushTag pushes the tag name in repo to remote.
+func PushTag(t T, repo *git.Repository, remote, name string) {
+	t.Helper()
+
+	refSpec := config.RefSpec("refs/tags/" + name + ":refs/tags/" + name)
+	require.NoError(t, repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{refSpec},
+	}))
+}
+
+// Git runs the git command with args in the repository at path,
+// and returns its output.
+func Git(t T, path string, args ...string) string {
+	t.Helper()
+
+	out, err := exec.Command("git", append([]string{"-C", path}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return string(out)
+}
+
+// MergeBranch merges branch into the current branch of the repository at path,
+// always creating a merge commit with message.
+func MergeBranch(t T, path, branch, message string) {
+	t.Helper()
+
+	Git(t, path, "merge", "--no-ff", "-m", message, branch)
+}
+
 func NewGitRepo(t T) (repo *git.Repository, path string) {
 	t.Helper()
 
@@ -3,7 +3,10 @@
 
 package mapper
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 func Convert(inc string) (Increment, error) {
 	switch inc {
@@ -21,6 +24,21 @@ func Convert(inc string) (Increment, error) {
 
 type Increment int
 
+// String returns the name of the increment, as accepted by Convert.
+func (i Increment) String() string {
+	switch i {
+	case IncrementMajor:
+		return "major"
+	case IncrementMinor:
+		return "minor"
+	case IncrementPatch:
+		return "patch"
+	case IncrementNone:
+		return "none"
+	}
+	return fmt.Sprintf("Increment(%d)", int(i))
+}
+
 const (
 	IncrementNone  = iota
 	IncrementPatch = iota
@@ -67,18 +85,33 @@ func NewTable(tm Mapper, defInc Increment) Table {
 	}
 }
 
+// Default returns the increment for commit types that have no mapping.
+func (t Table) Default() Increment {
+	return t.defaultInc
+}
+
 // Get returns the configured increment for the provided commit type. Returns the default increment if no mapping for
-// the input type is found.
+// the input type is found. Commit types are case-insensitive.
 func (t Table) Get(typ string) Increment {
 	// release type is always a patch increment
-	if typ == TypeRelease {
+	if IsRelease(typ) {
 		return IncrementPatch
 	}
 
-	inc, ok := t.Mapper[typ]
-	if !ok {
-		return t.defaultInc
+	if inc, ok := t.Mapper[strings.ToLower(typ)]; ok {
+		return inc
+	}
+
+	for k, inc := range t.Mapper {
+		if strings.EqualFold(k, typ) {
+			return inc
+		}
 	}
 
-	return inc
+	return t.defaultInc
+}
+
+// IsRelease returns true if typ is the release commit type, in any case.
+func IsRelease(typ string) bool {
+	return strings.EqualFold(typ, TypeRelease)
 }
@@ -96,6 +96,33 @@ func TestTypeTable_Get(t *testing.T) {
 			},
 			want: IncrementPatch,
 		},
+		{
+			name:  "type case",
+			index: "Feat",
+			table: Table{
+				Mapper:     defaultCommitTypeMapper,
+				defaultInc: IncrementPatch,
+			},
+			want: IncrementMinor,
+		},
+		{
+			name:  "mapping case",
+			index: "docs",
+			table: Table{
+				Mapper:     Mapper{"Docs": IncrementNone},
+				defaultInc: IncrementPatch,
+			},
+			want: IncrementNone,
+		},
+		{
+			name:  "release case",
+			index: "RELEASE",
+			table: Table{
+				Mapper:     Mapper{},
+				defaultInc: IncrementNone,
+			},
+			want: IncrementPatch,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t1 *testing.T) {
@@ -104,3 +131,22 @@ func TestTypeTable_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrement_String(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"major", "minor", "patch", "none"} {
+		inc, err := Convert(name)
+		if assert.NoError(t, err) {
+			assert.Equal(t, name, inc.String())
+		}
+	}
+
+	assert.Equal(t, "Increment(42)", Increment(42).String())
+}
+
+func TestTable_Default(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Increment(IncrementMinor), NewTable(nil, IncrementMinor).Default())
+}
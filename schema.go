@@ -0,0 +1,93 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is the subset of JSON Schema used to describe the config file.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+}
+
+// ConfigSchema returns a JSON Schema that describes the gotagger config file.
+func ConfigSchema() ([]byte, error) {
+	schema, err := structSchema(reflect.TypeOf(config{}))
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Schema = schemaDraft
+	schema.Title = "gotagger configuration"
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// structSchema returns the schema of the config struct t.
+func structSchema(t reflect.Type) (*jsonSchema, error) {
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+
+	for name, f := range structOptions(t) {
+		prop, err := typeSchema(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("config option %s: %w", name, err)
+		}
+
+		prop.Description = f.Tag.Get("description")
+		if enum := f.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+
+		schema.Properties[name] = prop
+	}
+
+	return schema, nil
+}
+
+// typeSchema returns the schema of a go type.
+func typeSchema(t reflect.Type) (*jsonSchema, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem())
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Slice:
+		items, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		values, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
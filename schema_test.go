@@ -0,0 +1,60 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSchema(t *testing.T) {
+	data, err := ConfigSchema()
+	require.NoError(t, err)
+
+	var schema struct {
+		Schema               string `json:"$schema"`
+		Type                 string
+		AdditionalProperties bool
+		Properties           map[string]struct {
+			Description string
+			Type        string
+			Enum        []string
+			Items       *struct{ Type string }
+		}
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	assert.Equal(t, schemaDraft, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.False(t, schema.AdditionalProperties)
+
+	// every config option is described
+	for name := range configOptions() {
+		if prop, ok := schema.Properties[name]; assert.True(t, ok, name) {
+			assert.NotEmpty(t, prop.Description, name)
+			assert.NotEmpty(t, prop.Type, name)
+		}
+	}
+
+	assert.Equal(t, "boolean", schema.Properties["pushTag"].Type)
+	assert.Equal(t, "string", schema.Properties["versionPrefix"].Type)
+	assert.Equal(t, []string{"minor", "patch", "none"}, schema.Properties["defaultIncrement"].Enum)
+	if prop := schema.Properties["paths"]; assert.Equal(t, "array", prop.Type) && assert.NotNil(t, prop.Items) {
+		assert.Equal(t, "string", prop.Items.Type)
+	}
+}
+
+func TestConfigSchema_gotaggerJSON(t *testing.T) {
+	// the example config file only uses known options
+	data, err := os.ReadFile("gotagger.json")
+	require.NoError(t, err)
+
+	var keys map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &keys))
+	assert.NoError(t, checkKeys(keys))
+}
@@ -0,0 +1,315 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sassoftware/gotagger/mapper"
+)
+
+const (
+	// SemVerStrategy is the name of the SemVer versioning strategy.
+	SemVerStrategy = "semver"
+
+	// CalVerStrategy is the name of the CalVer versioning strategy.
+	CalVerStrategy = "calver"
+
+	// DefaultCalVerLayout is the default layout of CalVer versions.
+	DefaultCalVerLayout = "YYYY.0M.MICRO"
+)
+
+// VersionStrategy calculates the versions of releases.
+//
+// Versions are represented as semver.Versions, whose major, minor, and patch
+// numbers hold the parts of the version, so that the tags of every strategy
+// are found and ordered the same way.
+type VersionStrategy interface {
+	// Name returns the name of the strategy.
+	Name() string
+
+	// Parse parses a version without its tag prefix.
+	// It returns an error if version is not valid for the strategy.
+	Parse(version string) (*semver.Version, error)
+
+	// Next returns the version that follows v for the increment inc.
+	Next(v *semver.Version, inc mapper.Increment) (*semver.Version, error)
+
+	// Format returns v as a string, without a tag prefix.
+	Format(v *semver.Version) string
+}
+
+// SemVer is the semantic versioning strategy.
+// Commits increment the major, minor, or patch version.
+type SemVer struct{}
+
+// Name returns "semver".
+func (SemVer) Name() string { return SemVerStrategy }
+
+// Parse parses a semantic version.
+func (SemVer) Parse(version string) (*semver.Version, error) {
+	return semver.NewVersion(version)
+}
+
+// Next increments the major, minor, or patch version of v.
+func (SemVer) Next(v *semver.Version, inc mapper.Increment) (*semver.Version, error) {
+	var next semver.Version
+	switch inc {
+	case mapper.IncrementMajor:
+		next = v.IncMajor()
+	case mapper.IncrementMinor:
+		next = v.IncMinor()
+	case mapper.IncrementPatch:
+		next = v.IncPatch()
+	default:
+		return v, nil
+	}
+
+	return &next, nil
+}
+
+// Format returns v without a "v" prefix.
+func (SemVer) Format(v *semver.Version) string {
+	return v.String()
+}
+
+// CalVer is the calendar versioning strategy.
+//
+// Versions are the date of the release, formatted by Layout,
+// and an optional MICRO counter of the releases made on that date.
+// The kind of increment does not matter, any increment creates a new version.
+type CalVer struct {
+	// Layout is two or three dot-separated parts,
+	// such as "YYYY.0M.MICRO" or "YY.0M.0D".
+	// Each part is one of:
+	//
+	//	YYYY   full year: 2006, 2026
+	//	YY     short year: 6, 26
+	//	0Y     zero-padded short year: 06, 26
+	//	MM     month: 1, 10
+	//	0M     zero-padded month: 01, 10
+	//	WW     ISO week of the year: 1, 52
+	//	0W     zero-padded ISO week of the year: 01, 52
+	//	DD     day of the month: 1, 31
+	//	0D     zero-padded day of the month: 01, 31
+	//	MICRO  release counter, which must be the last part
+	//
+	// Layouts with a week use the ISO year, so 2027-01-01,
+	// in week 53 of 2026, is "2026.53".
+	Layout string
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewCalVer returns a CalVer strategy for layout.
+// It returns an error if layout is not valid.
+func NewCalVer(layout string) (*CalVer, error) {
+	parts := strings.Split(layout, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, fmt.Errorf("invalid calver format %q: must have two or three parts", layout)
+	}
+
+	for i, part := range parts {
+		switch part {
+		case "YYYY", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D":
+		case "MICRO":
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("invalid calver format %q: MICRO must be the last part", layout)
+			}
+		default:
+			return nil, fmt.Errorf("invalid calver format %q: unknown part %q", layout, part)
+		}
+	}
+
+	return &CalVer{Layout: layout}, nil
+}
+
+// Name returns "calver".
+func (c *CalVer) Name() string { return CalVerStrategy }
+
+// Parse parses a version that matches the layout of c.
+func (c *CalVer) Parse(version string) (*semver.Version, error) {
+	parts := c.parts()
+	fields := strings.Split(version, ".")
+	if len(fields) != len(parts) {
+		return nil, fmt.Errorf("version %s does not match calver format %s", version, c.Layout)
+	}
+
+	for i, field := range fields {
+		if !validCalVerField(parts[i], field) {
+			return nil, fmt.Errorf("version %s does not match calver format %s", version, c.Layout)
+		}
+	}
+
+	return semver.NewVersion(version)
+}
+
+// Next returns the version for the current date.
+// If v was released on the current date,
+// then the MICRO part of v is incremented.
+// If v is the zero version, because there is no previous release,
+// then Next returns the first version of the current date, even if inc is none.
+func (c *CalVer) Next(v *semver.Version, inc mapper.Increment) (*semver.Version, error) {
+	if isZeroVersion(v) {
+		return semver.NewVersion(c.format(c.dateValues(c.now())))
+	}
+
+	if inc == mapper.IncrementNone {
+		return v, nil
+	}
+
+	parts := c.parts()
+	current := c.values(v)
+	next := c.dateValues(c.now())
+
+	// compare the date parts of the versions
+	newer := false
+	for i, part := range parts {
+		if part == "MICRO" || next[i] == current[i] {
+			continue
+		}
+
+		newer = next[i] > current[i]
+		break
+	}
+
+	if !newer {
+		// do not go back in time
+		next = current
+		if parts[len(parts)-1] != "MICRO" {
+			return nil, fmt.Errorf("version %s was already released: add MICRO to the calver format %s to release more than once per period", c.Format(v), c.Layout)
+		}
+		next[len(next)-1]++
+	}
+
+	return semver.NewVersion(c.format(next))
+}
+
+// Format returns v formatted by the layout of c.
+// The zero version, which means there is no previous release,
+// is formatted as the first version of the current date.
+func (c *CalVer) Format(v *semver.Version) string {
+	if isZeroVersion(v) {
+		return c.format(c.dateValues(c.now()))
+	}
+
+	return c.format(c.values(v))
+}
+
+// now returns the current time.
+func (c *CalVer) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+
+	return time.Now()
+}
+
+// format formats the values of each part.
+func (c *CalVer) format(values []uint64) string {
+	parts := c.parts()
+	fields := make([]string, len(parts))
+	for i, part := range parts {
+		switch part {
+		case "YYYY":
+			fields[i] = fmt.Sprintf("%04d", values[i])
+		case "0Y", "0M", "0W", "0D":
+			fields[i] = fmt.Sprintf("%02d", values[i])
+		default:
+			fields[i] = strconv.FormatUint(values[i], 10)
+		}
+	}
+
+	return strings.Join(fields, ".")
+}
+
+// values returns the value of each part of v.
+func (c *CalVer) values(v *semver.Version) []uint64 {
+	return []uint64{v.Major(), v.Minor(), v.Patch()}[:len(c.parts())]
+}
+
+// dateValues returns the value of each part for the date t.
+// MICRO is always 0.
+func (c *CalVer) dateValues(t time.Time) []uint64 {
+	parts := c.parts()
+
+	// the week belongs to the ISO year, which differs from the calendar year
+	// around the new year
+	year, week := t.ISOWeek()
+	weekly := false
+	for _, part := range parts {
+		weekly = weekly || part == "WW" || part == "0W"
+	}
+	if !weekly {
+		year = t.Year()
+	}
+
+	values := make([]uint64, len(parts))
+	for i, part := range parts {
+		switch part {
+		case "YYYY":
+			values[i] = uint64(year)
+		case "YY", "0Y":
+			values[i] = uint64(year % 100)
+		case "MM", "0M":
+			values[i] = uint64(t.Month())
+		case "WW", "0W":
+			values[i] = uint64(week)
+		case "DD", "0D":
+			values[i] = uint64(t.Day())
+		}
+	}
+
+	return values
+}
+
+// parts returns the parts of the layout of c.
+func (c *CalVer) parts() []string {
+	return strings.Split(c.Layout, ".")
+}
+
+// isZeroVersion returns true if v is 0.0.0,
+// the base version when there are no previous releases.
+func isZeroVersion(v *semver.Version) bool {
+	return v.Major() == 0 && v.Minor() == 0 && v.Patch() == 0
+}
+
+// validCalVerField returns true if field is a valid value for the layout part.
+func validCalVerField(part, field string) bool {
+	n, err := strconv.ParseUint(field, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	switch part {
+	case "YYYY":
+		return len(field) == 4
+	case "0Y", "0M", "0W", "0D":
+		if len(field) != 2 {
+			return false
+		}
+	default:
+		if len(field) > 1 && field[0] == '0' {
+			return false
+		}
+	}
+
+	switch part {
+	case "MM", "0M":
+		return n >= 1 && n <= 12
+	case "WW", "0W":
+		return n >= 1 && n <= 53
+	case "DD", "0D":
+		return n >= 1 && n <= 31
+	case "YY", "0Y":
+		return n <= 99
+	}
+
+	return true
+}
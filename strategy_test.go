@@ -0,0 +1,251 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sassoftware/gotagger/mapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemVer_Next(t *testing.T) {
+	tests := []struct {
+		inc  mapper.Increment
+		want string
+	}{
+		{mapper.IncrementMajor, "2.0.0"},
+		{mapper.IncrementMinor, "1.3.0"},
+		{mapper.IncrementPatch, "1.2.4"},
+		{mapper.IncrementNone, "1.2.3"},
+	}
+
+	for _, tt := range tests {
+		if v, err := (SemVer{}).Next(semver.MustParse("v1.2.3"), tt.inc); assert.NoError(t, err) {
+			assert.Equal(t, tt.want, SemVer{}.Format(v), tt.inc)
+		}
+	}
+}
+
+func TestNewCalVer(t *testing.T) {
+	tests := []struct {
+		layout  string
+		wantErr string
+	}{
+		{"YYYY.0M.MICRO", ""},
+		{"YY.0W.MICRO", ""},
+		{"YYYY.0M.0D", ""},
+		{"YYYY.MICRO", ""},
+		{"YYYY", `invalid calver format "YYYY": must have two or three parts`},
+		{"YYYY.0M.0D.MICRO", `invalid calver format "YYYY.0M.0D.MICRO": must have two or three parts`},
+		{"YYYY.MICRO.0M", `invalid calver format "YYYY.MICRO.0M": MICRO must be the last part`},
+		{"YYYY.mm.MICRO", `invalid calver format "YYYY.mm.MICRO": unknown part "mm"`},
+	}
+
+	for _, tt := range tests {
+		_, err := NewCalVer(tt.layout)
+		if tt.wantErr == "" {
+			assert.NoError(t, err, tt.layout)
+		} else {
+			assert.EqualError(t, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCalVer_Parse(t *testing.T) {
+	tests := []struct {
+		layout  string
+		version string
+		valid   bool
+	}{
+		{"YYYY.0M.MICRO", "2026.10.3", true},
+		{"YYYY.0M.MICRO", "2026.01.0", true},
+		{"YYYY.0M.MICRO", "2026.1.0", false},
+		{"YYYY.0M.MICRO", "2026.13.0", false},
+		{"YYYY.0M.MICRO", "26.01.0", false},
+		{"YYYY.0M.MICRO", "2026.01.01", false},
+		{"YYYY.0M.MICRO", "2026.01", false},
+		{"YYYY.0M.MICRO", "1.0.0", false},
+		{"YY.MM.DD", "26.1.31", true},
+		{"YY.MM.DD", "26.1.32", false},
+		{"0Y.0W.MICRO", "06.53.2", true},
+	}
+
+	for _, tt := range tests {
+		c, err := NewCalVer(tt.layout)
+		require.NoError(t, err)
+
+		v, err := c.Parse(tt.version)
+		if tt.valid {
+			if assert.NoError(t, err, tt.version) {
+				assert.Equal(t, tt.version, c.Format(v))
+			}
+		} else {
+			assert.Error(t, err, tt.version)
+		}
+	}
+}
+
+func TestCalVer_Format_zero(t *testing.T) {
+	c := &CalVer{
+		Layout: DefaultCalVerLayout,
+		Now:    func() time.Time { return time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC) },
+	}
+
+	// there is no previous release, so the version is the current period
+	assert.Equal(t, "2026.10.0", c.Format(&semver.Version{}))
+}
+
+func TestCalVer_Next(t *testing.T) {
+	now := func() time.Time { return time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		title   string
+		layout  string
+		version string
+		inc     mapper.Increment
+		want    string
+		wantErr string
+	}{
+		{
+			title:   "first release",
+			layout:  "YYYY.0M.MICRO",
+			version: "0.0.0",
+			inc:     mapper.IncrementPatch,
+			want:    "2026.10.0",
+		},
+		{
+			title:   "first release without changes",
+			layout:  "YYYY.0M.MICRO",
+			version: "0.0.0",
+			inc:     mapper.IncrementNone,
+			want:    "2026.10.0",
+		},
+		{
+			title:   "first release without micro",
+			layout:  "YY.0M.0D",
+			version: "0.0.0",
+			inc:     mapper.IncrementNone,
+			want:    "26.10.18",
+		},
+		{
+			title:   "new month",
+			layout:  "YYYY.0M.MICRO",
+			version: "2026.9.4",
+			inc:     mapper.IncrementMajor,
+			want:    "2026.10.0",
+		},
+		{
+			title:   "same month",
+			layout:  "YYYY.0M.MICRO",
+			version: "2026.10.3",
+			inc:     mapper.IncrementMinor,
+			want:    "2026.10.4",
+		},
+		{
+			title:   "no increment",
+			layout:  "YYYY.0M.MICRO",
+			version: "2026.9.4",
+			inc:     mapper.IncrementNone,
+			want:    "2026.09.4",
+		},
+		{
+			title:   "future release",
+			layout:  "YYYY.0M.MICRO",
+			version: "2027.1.0",
+			inc:     mapper.IncrementPatch,
+			want:    "2027.01.1",
+		},
+		{
+			title:   "week",
+			layout:  "0Y.0W.MICRO",
+			version: "26.41.7",
+			inc:     mapper.IncrementPatch,
+			want:    "26.42.0",
+		},
+		{
+			title:   "day",
+			layout:  "YYYY.0M.0D",
+			version: "2026.10.17",
+			inc:     mapper.IncrementPatch,
+			want:    "2026.10.18",
+		},
+		{
+			title:   "day already released",
+			layout:  "YYYY.0M.0D",
+			version: "2026.10.18",
+			inc:     mapper.IncrementPatch,
+			wantErr: "version 2026.10.18 was already released: add MICRO to the calver format YYYY.0M.0D to release more than once per period",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			c := &CalVer{Layout: tt.layout, Now: now}
+
+			v, err := c.Next(semver.MustParse(tt.version), tt.inc)
+			if tt.wantErr == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, c.Format(v))
+				}
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalVer_Next_isoWeek(t *testing.T) {
+	tests := []struct {
+		title   string
+		layout  string
+		now     time.Time
+		version string
+		want    string
+	}{
+		{
+			title:   "last week of the iso year",
+			layout:  "YYYY.0W.MICRO",
+			now:     time.Date(2027, time.January, 1, 12, 0, 0, 0, time.UTC),
+			version: "2026.52.3",
+			want:    "2026.53.0",
+		},
+		{
+			title:   "first week of the iso year",
+			layout:  "0Y.WW.MICRO",
+			now:     time.Date(2027, time.January, 4, 12, 0, 0, 0, time.UTC),
+			version: "26.53.0",
+			want:    "27.1.0",
+		},
+		{
+			title:   "first week of the next iso year",
+			layout:  "YYYY.WW.MICRO",
+			now:     time.Date(2025, time.December, 29, 12, 0, 0, 0, time.UTC),
+			version: "2025.52.0",
+			want:    "2026.1.0",
+		},
+		{
+			title:   "months use the calendar year",
+			layout:  "YYYY.0M.MICRO",
+			now:     time.Date(2027, time.January, 1, 12, 0, 0, 0, time.UTC),
+			version: "2026.12.3",
+			want:    "2027.01.0",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			c := &CalVer{Layout: tt.layout, Now: func() time.Time { return tt.now }}
+
+			if v, err := c.Next(semver.MustParse(tt.version), mapper.IncrementPatch); assert.NoError(t, err) {
+				assert.Equal(t, tt.want, c.Format(v))
+			}
+		})
+	}
+}
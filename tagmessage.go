@@ -0,0 +1,103 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"strings"
+	"text/template"
+)
+
+// TagMessageData is the data available to the TagMessage template.
+type TagMessageData struct {
+	// Tag is the name of the tag being created.
+	Tag string
+
+	// Prefix is the prefix of the tag.
+	Prefix string
+
+	// Version is the version being released, without the prefix.
+	Version string
+
+	// PreviousTag is the name of the tag of the previous release.
+	// It is empty if this is the first release.
+	PreviousTag string
+
+	// PreviousVersion is the version of the previous release, without the prefix.
+	// It is empty if this is the first release.
+	PreviousVersion string
+
+	// Module is the name of the go module being released.
+	// It is empty if gotagger is not versioning go modules.
+	Module string
+
+	// Component is the name of the component being released.
+	// It is empty if gotagger is not versioning components.
+	Component string
+
+	// Path is the path being released.
+	// It is empty if gotagger is versioning go modules or components.
+	Path string
+
+	// Commits are the commits since the previous release,
+	// most recent first.
+	Commits []TagMessageCommit
+}
+
+// TagMessageCommit is a commit in the TagMessageData.
+type TagMessageCommit struct {
+	Hash     string
+	Header   string
+	Type     string
+	Scope    string
+	Subject  string
+	Breaking bool
+}
+
+func newTagMessageData(r release) TagMessageData {
+	data := TagMessageData{
+		Tag:             r.tag(),
+		Prefix:          r.prefix,
+		Version:         r.version,
+		PreviousVersion: r.previous,
+		Module:          r.module.name,
+		Component:       r.component,
+		Path:            r.path,
+		Commits:         make([]TagMessageCommit, len(r.commits)),
+	}
+
+	if r.previous != "" {
+		data.PreviousTag = r.prefix + r.previous
+	}
+
+	for i, c := range r.commits {
+		data.Commits[i] = TagMessageCommit{
+			Hash:     c.Hash,
+			Header:   c.Header,
+			Type:     c.Type,
+			Scope:    c.Scope,
+			Subject:  c.Subject,
+			Breaking: c.Breaking,
+		}
+	}
+
+	return data
+}
+
+func parseTagMessage(text string) (*template.Template, error) {
+	return template.New("tagMessage").Option("missingkey=error").Parse(text)
+}
+
+func renderTagMessage(text string, data TagMessageData) (string, error) {
+	tmpl, err := parseTagMessage(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
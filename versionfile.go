@@ -0,0 +1,494 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrVersionFilesOutOfSync is returned by CheckFiles when a version file does
+// not contain the version of the latest release.
+var ErrVersionFilesOutOfSync = errors.New("version files are out of sync with the latest release")
+
+var tomlKeyValue = regexp.MustCompile(`^\s*([^=#\[]+?)\s*=\s*(["'])([^"'\n]*)(["'])`)
+
+// VersionFile is a file that contains the version of the project,
+// such as a package.json or a version.go.
+//
+// The version is found either by Pattern, or by Key.
+type VersionFile struct {
+	// Path is the path of the file, relative to the top of the repository.
+	Path string
+
+	// Pattern is a regular expression that matches the version.
+	// The version is the first capture group of the pattern,
+	// or the whole match if the pattern has no capture groups.
+	// Every match is updated.
+	Pattern string
+
+	// Key is the dotted path of the version in a JSON, YAML, or TOML file,
+	// such as "version" or "tool.poetry.version".
+	// The rest of the file is left as is.
+	Key string
+
+	// Format is the output format of the version in the file.
+	// Defaults to FormatSemVer, which does not include the tag prefix.
+	Format string
+
+	// Component is the name of the component, go module, or path whose version
+	// is written to the file. Defaults to the first version gotagger finds.
+	Component string
+}
+
+// BumpFiles writes the current versions to the version files,
+// and returns the paths of the files that changed.
+//
+// Run BumpFiles before creating a release commit,
+// so the release contains the files with its version.
+func (g *Gotagger) BumpFiles() ([]string, error) {
+	if !g.isHead() {
+		return nil, errors.New("version files can only be updated at HEAD")
+	}
+
+	releases, err := g.versionFileReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := g.repo.Toplevel()
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, f := range g.Config.VersionFiles {
+		logger := g.logger.WithValues("path", f.Path)
+
+		r, err := selectRelease(releases, f.Component)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+
+		version, err := RenderVersion(r.version, f.Format)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+
+		fn := filepath.Join(root, filepath.FromSlash(f.Path))
+		info, err := os.Stat(fn)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(fn)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := f.replace(data, version)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(data, updated) {
+			logger.Info("version file is up to date", "version", version)
+			continue
+		}
+
+		logger.Info("updating version file", "version", version)
+		if err := os.WriteFile(fn, updated, info.Mode().Perm()); err != nil {
+			return nil, err
+		}
+		changed = append(changed, f.Path)
+	}
+
+	return changed, nil
+}
+
+// CheckFiles returns an error that wraps ErrVersionFilesOutOfSync
+// if any of the version files does not contain the version of the latest release.
+//
+// If the configured revision is HEAD, then the files in the worktree are checked.
+// Otherwise, the files are read from the revision.
+func (g *Gotagger) CheckFiles() error {
+	releases, err := g.versionFileReleases()
+	if err != nil {
+		return err
+	}
+
+	root, err := g.repo.Toplevel()
+	if err != nil {
+		return err
+	}
+
+	var msgs []string
+	for _, f := range g.Config.VersionFiles {
+		r, err := selectRelease(releases, f.Component)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Path, err)
+		}
+
+		if r.previous == "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s has not been released", f.Path, r.name()))
+			continue
+		}
+
+		want, err := RenderVersion(r.previous, f.Format)
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Path, err)
+		}
+
+		var data []byte
+		if g.isHead() {
+			data, err = os.ReadFile(filepath.Join(root, filepath.FromSlash(f.Path)))
+		} else {
+			data, err = g.repo.ReadFile(g.rev(), f.Path)
+		}
+		if err != nil {
+			return err
+		}
+
+		versions, err := f.versions(data)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range versions {
+			if v != want {
+				msgs = append(msgs, fmt.Sprintf("%s: version is %s, want %s", f.Path, v, want))
+				break
+			}
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("%w:\n%s", ErrVersionFilesOutOfSync, strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+// versionFileReleases returns the versions of every module, component, or path.
+func (g *Gotagger) versionFileReleases() ([]release, error) {
+	var modules []module
+	if !g.Config.IgnoreModules {
+		m, err := g.findAllModules(nil)
+		if err != nil {
+			return nil, err
+		}
+		modules = m
+	}
+
+	return g.versions(modules, nil)
+}
+
+// selectRelease returns the release for the component, go module, or path name,
+// or the first release if name is empty.
+func selectRelease(releases []release, name string) (release, error) {
+	if name == "" {
+		return releases[0], nil
+	}
+
+	for _, r := range releases {
+		if r.component == name || r.module.name == name || r.path == name ||
+			(r.module.name != "" && filepath.ToSlash(r.module.path) == name) {
+			return r, nil
+		}
+	}
+
+	return release{}, fmt.Errorf("no component, go module, or path named %s", name)
+}
+
+// name returns the name of the component, go module, or path of r.
+func (r release) name() string {
+	switch {
+	case r.component != "":
+		return r.component
+	case r.module.name != "":
+		return r.module.name
+	default:
+		return r.path
+	}
+}
+
+// versions returns the versions in data.
+func (f VersionFile) versions(data []byte) ([]string, error) {
+	spans, err := f.find(data)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, len(spans))
+	for i, s := range spans {
+		versions[i] = string(data[s[0]:s[1]])
+	}
+
+	return versions, nil
+}
+
+// replace returns data with every version replaced by version.
+func (f VersionFile) replace(data []byte, version string) ([]byte, error) {
+	spans, err := f.find(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	last := 0
+	for _, s := range spans {
+		b.Write(data[last:s[0]])
+		b.WriteString(version)
+		last = s[1]
+	}
+	b.Write(data[last:])
+
+	return b.Bytes(), nil
+}
+
+// find returns the start and end offsets of the versions in data.
+func (f VersionFile) find(data []byte) ([][2]int, error) {
+	if f.Pattern != "" {
+		return f.findPattern(data)
+	}
+
+	path := strings.Split(f.Key, ".")
+
+	var (
+		start, end int
+		err        error
+	)
+	switch ext := strings.ToLower(filepath.Ext(f.Path)); ext {
+	case ".json":
+		start, end, err = jsonKeySpan(data, path)
+	case ".yaml", ".yml":
+		start, end, err = yamlKeySpan(data, path)
+	case ".toml":
+		start, end, err = tomlKeySpan(data, path)
+	default:
+		err = fmt.Errorf("keys are not supported in %s files", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: key %s: %w", f.Path, f.Key, err)
+	}
+
+	return [][2]int{{start, end}}, nil
+}
+
+// findPattern returns the offsets of the versions matched by the pattern of f.
+func (f VersionFile) findPattern(data []byte) ([][2]int, error) {
+	re, err := regexp.Compile(f.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// use the first capture group if there is one
+	group := 0
+	if re.NumSubexp() > 0 {
+		group = 1
+	}
+
+	var spans [][2]int
+	for _, m := range re.FindAllSubmatchIndex(data, -1) {
+		if m[2*group] >= 0 {
+			spans = append(spans, [2]int{m[2*group], m[2*group+1]})
+		}
+	}
+
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("%s: pattern %s does not match", f.Path, f.Pattern)
+	}
+
+	return spans, nil
+}
+
+// jsonKeySpan returns the offsets of the string value at path in the JSON data.
+func jsonKeySpan(data []byte, path []string) (int, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for depth := range path {
+		if tok, err := dec.Token(); err != nil {
+			return 0, 0, err
+		} else if tok != json.Delim('{') {
+			return 0, 0, fmt.Errorf("%s is not an object", strings.Join(path[:depth], "."))
+		}
+
+		found := false
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return 0, 0, err
+			}
+
+			if tok == path[depth] {
+				found = true
+				break
+			}
+
+			// skip the value of other keys
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return 0, 0, err
+			}
+		}
+
+		if !found {
+			return 0, 0, errors.New("not found")
+		}
+	}
+
+	// the value starts after the separator that follows the key
+	offset := int(dec.InputOffset())
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if _, ok := tok.(string); !ok {
+		return 0, 0, errors.New("not a string")
+	}
+
+	end := int(dec.InputOffset())
+	start := offset + bytes.IndexByte(data[offset:end], '"')
+	if !bytes.Equal(data[start+1:end-1], []byte(tok.(string))) {
+		return 0, 0, errors.New("escaped strings are not supported")
+	}
+
+	return start + 1, end - 1, nil
+}
+
+// yamlKeySpan returns the offsets of the scalar value at path in the YAML data.
+func yamlKeySpan(data []byte, path []string) (int, int, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0, 0, err
+	}
+
+	if len(doc.Content) == 0 {
+		return 0, 0, errors.New("not found")
+	}
+
+	node := doc.Content[0]
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return 0, 0, errors.New("not found")
+		}
+
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+				break
+			}
+		}
+
+		if value == nil {
+			return 0, 0, errors.New("not found")
+		}
+		node = value
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return 0, 0, errors.New("not a scalar")
+	}
+
+	// find the offset of the node from its line and column
+	start := 0
+	for line := 1; line < node.Line; line++ {
+		i := bytes.IndexByte(data[start:], '\n')
+		if i < 0 {
+			return 0, 0, io.ErrUnexpectedEOF
+		}
+		start += i + 1
+	}
+	line := data[start:]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	// columns count characters, not bytes
+	column := []rune(string(line))
+	if node.Column-1 > len(column) {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	start += len(string(column[:node.Column-1]))
+
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		start++
+	}
+
+	end := start + len(node.Value)
+	if end > len(data) || string(data[start:end]) != node.Value {
+		return 0, 0, errors.New("escaped and multi-line strings are not supported")
+	}
+
+	return start, end, nil
+}
+
+// tomlKeySpan returns the offsets of the string value at path in the TOML data.
+//
+// Only single-line strings in tables, or at the top level, are supported.
+func tomlKeySpan(data []byte, path []string) (int, int, error) {
+	// make sure the file is valid
+	var m map[string]interface{}
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return 0, 0, err
+	}
+
+	want := strings.Join(path, ".")
+	table := ""
+	offset := 0
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		lineOffset := offset
+		offset += len(line)
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "[["):
+			// keys in arrays of tables are not supported
+			table = "[["
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			if i := strings.Index(trimmed, "]"); i > 0 {
+				table = normalizeTOMLKey(trimmed[1:i])
+			}
+			continue
+		}
+
+		match := tomlKeyValue.FindStringSubmatchIndex(line)
+		if match == nil || line[match[4]:match[5]] != line[match[8]:match[9]] {
+			continue
+		}
+
+		key := normalizeTOMLKey(line[match[2]:match[3]])
+		if table != "" {
+			key = table + "." + key
+		}
+
+		if key == want {
+			return lineOffset + match[6], lineOffset + match[7], nil
+		}
+	}
+
+	return 0, 0, errors.New("not found")
+}
+
+// normalizeTOMLKey removes whitespace and quotes from the parts of a dotted key.
+func normalizeTOMLKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,171 @@
+// Copyright © 2020, SAS Institute Inc., Cary, NC, USA.  All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package gotagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sassoftware/gotagger/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionFile_replace(t *testing.T) {
+	tests := []struct {
+		title   string
+		file    VersionFile
+		data    string
+		want    string
+		wantErr string
+	}{
+		{
+			title: "pattern with group",
+			file:  VersionFile{Path: "version.go", Pattern: `Version = "v?([^"]*)"`},
+			data:  "package foo\n\nconst Version = \"1.0.0\"\n",
+			want:  "package foo\n\nconst Version = \"1.2.3\"\n",
+		},
+		{
+			title: "pattern without group",
+			file:  VersionFile{Path: "VERSION", Pattern: `\d+\.\d+\.\d+`},
+			data:  "1.0.0 and 1.1.0\n",
+			want:  "1.2.3 and 1.2.3\n",
+		},
+		{
+			title:   "pattern does not match",
+			file:    VersionFile{Path: "VERSION", Pattern: `version: (.*)`},
+			data:    "1.0.0\n",
+			wantErr: "VERSION: pattern version: (.*) does not match",
+		},
+		{
+			title: "json",
+			file:  VersionFile{Path: "package.json", Key: "version"},
+			data:  "{\n\t\"name\": \"foo\",\n\t\"scripts\": {\"version\": \"echo\"},\n\t\"version\" :  \"1.0.0\"\n}\n",
+			want:  "{\n\t\"name\": \"foo\",\n\t\"scripts\": {\"version\": \"echo\"},\n\t\"version\" :  \"1.2.3\"\n}\n",
+		},
+		{
+			title: "nested json",
+			file:  VersionFile{Path: "app.json", Key: "app.version"},
+			data:  `{"version": "0.1.0", "app": {"name": "foo", "version": "1.0.0"}}`,
+			want:  `{"version": "0.1.0", "app": {"name": "foo", "version": "1.2.3"}}`,
+		},
+		{
+			title:   "json key not found",
+			file:    VersionFile{Path: "package.json", Key: "app.version"},
+			data:    `{"app": {"name": "foo"}}`,
+			wantErr: "package.json: key app.version: not found",
+		},
+		{
+			title:   "json key not a string",
+			file:    VersionFile{Path: "package.json", Key: "version"},
+			data:    `{"version": 1}`,
+			wantErr: "package.json: key version: not a string",
+		},
+		{
+			title: "yaml",
+			file:  VersionFile{Path: "Chart.yaml", Key: "appVersion"},
+			data:  "apiVersion: v2\nname: föö\nversion: 0.1.0\nappVersion: \"1.0.0\" # app\n",
+			want:  "apiVersion: v2\nname: föö\nversion: 0.1.0\nappVersion: \"1.2.3\" # app\n",
+		},
+		{
+			title: "nested yaml",
+			file:  VersionFile{Path: "values.yml", Key: "image.tag"},
+			data:  "image:\n  name: foo\n  tag: 1.0.0\n",
+			want:  "image:\n  name: foo\n  tag: 1.2.3\n",
+		},
+		{
+			title:   "yaml key not a scalar",
+			file:    VersionFile{Path: "values.yml", Key: "image"},
+			data:    "image:\n  tag: 1.0.0\n",
+			wantErr: "values.yml: key image: not a scalar",
+		},
+		{
+			title: "toml",
+			file:  VersionFile{Path: "pyproject.toml", Key: "tool.poetry.version"},
+			data:  "[project]\nversion = \"0.1.0\"\n\n[tool.poetry]\nname = \"foo\"\nversion = '1.0.0'\n",
+			want:  "[project]\nversion = \"0.1.0\"\n\n[tool.poetry]\nname = \"foo\"\nversion = '1.2.3'\n",
+		},
+		{
+			title: "toml dotted key",
+			file:  VersionFile{Path: "Cargo.toml", Key: "package.version"},
+			data:  "package.name = \"foo\"\npackage.version = \"1.0.0\"\n",
+			want:  "package.name = \"foo\"\npackage.version = \"1.2.3\"\n",
+		},
+		{
+			title:   "toml key not found",
+			file:    VersionFile{Path: "pyproject.toml", Key: "project.version"},
+			data:    "[tool.poetry]\nversion = \"1.0.0\"\n",
+			wantErr: "pyproject.toml: key project.version: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.title, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.file.replace([]byte(tt.data), "1.2.3")
+			if tt.wantErr == "" {
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, string(got))
+				}
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGotagger_BumpFiles(t *testing.T) {
+	g, repo, path := newGotagger(t)
+
+	testutils.CommitFile(t, repo, path, "package.json", "feat: add package.json", []byte("{\"version\": \"0.0.0\"}\n"))
+	testutils.CommitFile(t, repo, path, "version.go", "feat: add version.go", []byte("package foo\n\nconst Version = \"v0.0.0\"\n"))
+	testutils.CreateTag(t, repo, "v1.0.0")
+	testutils.CommitFile(t, repo, path, "foo.go", "feat: add foo", []byte("package foo\n"))
+
+	require.NoError(t, g.Config.ParseJSON([]byte(`{
+	"versionFiles": [
+		{"path": "package.json", "key": "version"},
+		{"path": "version.go", "pattern": "Version = \"v([^\"]*)\"", "format": "semver"}
+	]
+}`)))
+
+	// the files do not contain the released version
+	err := g.CheckFiles()
+	if assert.ErrorIs(t, err, ErrVersionFilesOutOfSync) {
+		assert.EqualError(t, err, "version files are out of sync with the latest release:\npackage.json: version is 0.0.0, want 1.0.0\nversion.go: version is 0.0.0, want 1.0.0")
+	}
+
+	if files, err := g.BumpFiles(); assert.NoError(t, err) {
+		assert.Equal(t, []string{"package.json", "version.go"}, files)
+	}
+
+	packageJSON, err := os.ReadFile(filepath.Join(path, "package.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\"version\": \"1.1.0\"}\n", string(packageJSON))
+
+	versionGo, err := os.ReadFile(filepath.Join(path, "version.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package foo\n\nconst Version = \"v1.1.0\"\n", string(versionGo))
+
+	// bumping again does not change anything
+	if files, err := g.BumpFiles(); assert.NoError(t, err) {
+		assert.Empty(t, files)
+	}
+
+	// the files are in sync once the release is tagged
+	testutils.CommitFile(t, repo, path, "package.json", "chore: bump package.json", packageJSON)
+	testutils.CommitFile(t, repo, path, "version.go", "release: 1.1.0", versionGo)
+	testutils.CreateTag(t, repo, "v1.1.0")
+	assert.NoError(t, g.CheckFiles())
+
+	// the files in other revisions are checked
+	g.Config.Rev = "HEAD~1"
+	assert.ErrorIs(t, g.CheckFiles(), ErrVersionFilesOutOfSync)
+
+	_, err = g.BumpFiles()
+	assert.EqualError(t, err, "version files can only be updated at HEAD")
+}